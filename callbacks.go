@@ -0,0 +1,386 @@
+package firmafon
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CallbackState is the state of a Callback in a CallbackQueue.
+type CallbackState string
+
+const (
+	CallbackOpen     CallbackState = "open"
+	CallbackAssigned CallbackState = "assigned"
+	CallbackResolved CallbackState = "resolved"
+)
+
+// CallbackResolution describes how a Callback was resolved.
+type CallbackResolution string
+
+const (
+	// CallbackCalledBack means an outgoing call to the number was answered.
+	CallbackCalledBack CallbackResolution = "called_back"
+
+	// CallbackCalledIn means the number called in again and was answered.
+	CallbackCalledIn CallbackResolution = "called_in"
+
+	// CallbackManual means the callback was resolved with CallbackQueue.Resolve.
+	CallbackManual CallbackResolution = "manual"
+)
+
+var (
+	// ErrCallbackNotFound is returned when a callback does not exist in the store.
+	ErrCallbackNotFound = errors.New("firmafon: callback not found")
+
+	// ErrCallbackResolved is returned when changing a callback that is already resolved.
+	ErrCallbackResolved = errors.New("firmafon: callback already resolved")
+)
+
+// A Callback is a number that has missed one or more inbound calls and is
+// waiting to be called back.
+type Callback struct {
+	// ID is the UUID of the first missed call.
+	ID string
+	// Number is the calling number in E.164 format.
+	Number string
+
+	// MissedCalls holds the UUIDs of the missed calls from Number.
+	MissedCalls   []string
+	FirstMissedAt time.Time
+	LastMissedAt  time.Time
+
+	// Attempts holds the UUIDs of outgoing calls to Number that were not answered.
+	Attempts []string
+
+	State      CallbackState
+	AssignedTo int // employee ID
+	AssignedAt time.Time
+
+	Resolution CallbackResolution
+	ResolvedAt time.Time
+	// ResolvedBy is the UUID of the call that resolved the callback, if any.
+	ResolvedBy string
+}
+
+func (cb *Callback) copy() *Callback {
+	c := *cb
+	c.MissedCalls = append([]string(nil), cb.MissedCalls...)
+	c.Attempts = append([]string(nil), cb.Attempts...)
+	return &c
+}
+
+// CallbackStore persists the callbacks of a CallbackQueue. Implementations
+// must be safe for concurrent use.
+type CallbackStore interface {
+	// Get returns the callback with the given ID or ErrCallbackNotFound.
+	Get(id string) (*Callback, error)
+
+	// FindOpen returns the unresolved callback for number or ErrCallbackNotFound.
+	FindOpen(number string) (*Callback, error)
+
+	// Save creates or replaces a callback.
+	Save(cb *Callback) error
+
+	// List returns all callbacks.
+	List() ([]*Callback, error)
+}
+
+// MemoryCallbackStore is a CallbackStore that keeps callbacks in memory.
+type MemoryCallbackStore struct {
+	mu        sync.Mutex
+	callbacks map[string]*Callback
+}
+
+// NewMemoryCallbackStore returns an empty MemoryCallbackStore.
+func NewMemoryCallbackStore() *MemoryCallbackStore {
+	return &MemoryCallbackStore{callbacks: make(map[string]*Callback)}
+}
+
+func (s *MemoryCallbackStore) Get(id string) (*Callback, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cb, ok := s.callbacks[id]
+	if !ok {
+		return nil, ErrCallbackNotFound
+	}
+
+	return cb.copy(), nil
+}
+
+func (s *MemoryCallbackStore) FindOpen(number string) (*Callback, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, cb := range s.callbacks {
+		if cb.Number == number && cb.State != CallbackResolved {
+			return cb.copy(), nil
+		}
+	}
+
+	return nil, ErrCallbackNotFound
+}
+
+func (s *MemoryCallbackStore) Save(cb *Callback) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.callbacks[cb.ID] = cb.copy()
+	return nil
+}
+
+func (s *MemoryCallbackStore) List() ([]*Callback, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cbs := make([]*Callback, 0, len(s.callbacks))
+	for _, cb := range s.callbacks {
+		cbs = append(cbs, cb.copy())
+	}
+
+	return cbs, nil
+}
+
+// A CallbackQueue tracks missed inbound calls until the caller has been
+// called back successfully or has called in again and been answered.
+type CallbackQueue struct {
+	store CallbackStore
+	now   func() time.Time
+
+	mu sync.Mutex
+}
+
+// NewCallbackQueue returns a CallbackQueue backed by store. If store is nil a
+// MemoryCallbackStore is used.
+func NewCallbackQueue(store CallbackStore) *CallbackQueue {
+	if store == nil {
+		store = NewMemoryCallbackStore()
+	}
+
+	return &CallbackQueue{store: store, now: time.Now}
+}
+
+// Process feeds a list of calls, such as the result of CallsService.GetAll,
+// to the queue. The calls are processed in the order they started.
+func (q *CallbackQueue) Process(calls []*Call) error {
+	sorted := make([]*Call, len(calls))
+	copy(sorted, calls)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartedAt.Before(sorted[j].StartedAt)
+	})
+
+	for _, c := range sorted {
+		if err := q.ProcessCall(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Consume processes calls from ch until it is closed. Calls are expected to
+// arrive in the order they started.
+func (q *CallbackQueue) Consume(ch <-chan *Call) error {
+	for c := range ch {
+		if err := q.ProcessCall(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ProcessCall feeds a single call to the queue. Processing the same call more
+// than once has no effect.
+func (q *CallbackQueue) ProcessCall(c *Call) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	answered := c.Status == CallStatusAnswered
+	switch c.Direction {
+	case CallDirectionIncoming:
+		if answered {
+			return q.resolve(c, c.FromNumber, CallbackCalledIn)
+		}
+		return q.missed(c)
+	case CallDirectionOutgoing:
+		if answered {
+			return q.resolve(c, c.ToNumber, CallbackCalledBack)
+		}
+		return q.attempt(c)
+	}
+
+	return nil
+}
+
+func (q *CallbackQueue) missed(c *Call) error {
+	number := callbackNumber(c.FromNumber)
+	if number == "" {
+		// hidden numbers can't be called back
+		return nil
+	}
+
+	cb, err := q.store.FindOpen(number)
+	if err == ErrCallbackNotFound {
+		stale, err := q.resolvedSince(number, c.StartedAt)
+		if err != nil || stale {
+			return err
+		}
+		return q.store.Save(&Callback{
+			ID:            c.CallUUID,
+			Number:        number,
+			MissedCalls:   []string{c.CallUUID},
+			FirstMissedAt: c.StartedAt,
+			LastMissedAt:  c.StartedAt,
+			State:         CallbackOpen,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if containsString(cb.MissedCalls, c.CallUUID) {
+		return nil
+	}
+	cb.MissedCalls = append(cb.MissedCalls, c.CallUUID)
+	if c.StartedAt.After(cb.LastMissedAt) {
+		cb.LastMissedAt = c.StartedAt
+	}
+
+	return q.store.Save(cb)
+}
+
+func (q *CallbackQueue) resolve(c *Call, number string, resolution CallbackResolution) error {
+	cb, err := q.store.FindOpen(callbackNumber(number))
+	if err == ErrCallbackNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if c.StartedAt.Before(cb.LastMissedAt) {
+		return nil
+	}
+
+	cb.State = CallbackResolved
+	cb.Resolution = resolution
+	cb.ResolvedAt = c.StartedAt
+	cb.ResolvedBy = c.CallUUID
+
+	return q.store.Save(cb)
+}
+
+func (q *CallbackQueue) attempt(c *Call) error {
+	cb, err := q.store.FindOpen(callbackNumber(c.ToNumber))
+	if err == ErrCallbackNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if c.StartedAt.Before(cb.LastMissedAt) || containsString(cb.Attempts, c.CallUUID) {
+		return nil
+	}
+
+	cb.Attempts = append(cb.Attempts, c.CallUUID)
+	return q.store.Save(cb)
+}
+
+// resolvedSince reports whether a callback for number was resolved after t,
+// which is the case when an old missed call is processed again.
+func (q *CallbackQueue) resolvedSince(number string, t time.Time) (bool, error) {
+	cbs, err := q.store.List()
+	if err != nil {
+		return false, err
+	}
+
+	for _, cb := range cbs {
+		if cb.Number == number && cb.State == CallbackResolved && !cb.ResolvedAt.Before(t) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Pending returns the unresolved callbacks, oldest first.
+func (q *CallbackQueue) Pending() ([]*Callback, error) {
+	cbs, err := q.store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := cbs[:0]
+	for _, cb := range cbs {
+		if cb.State != CallbackResolved {
+			pending = append(pending, cb)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].FirstMissedAt.Before(pending[j].FirstMissedAt)
+	})
+
+	return pending, nil
+}
+
+// Assign assigns the callback with the given ID to an employee.
+func (q *CallbackQueue) Assign(id string, employeeID int) (*Callback, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	cb, err := q.store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if cb.State == CallbackResolved {
+		return nil, ErrCallbackResolved
+	}
+
+	cb.State = CallbackAssigned
+	cb.AssignedTo = employeeID
+	cb.AssignedAt = q.now()
+
+	return cb, q.store.Save(cb)
+}
+
+// Resolve manually resolves the callback with the given ID.
+func (q *CallbackQueue) Resolve(id string) (*Callback, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	cb, err := q.store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if cb.State == CallbackResolved {
+		return nil, ErrCallbackResolved
+	}
+
+	cb.State = CallbackResolved
+	cb.Resolution = CallbackManual
+	cb.ResolvedAt = q.now()
+
+	return cb, q.store.Save(cb)
+}
+
+// callbackNumber normalizes number so the same number in different formats
+// maps to the same callback. Hidden numbers map to "".
+func callbackNumber(number string) string {
+	if n := PhoneNumber(number).E164(); n != "" {
+		return n
+	}
+
+	return strings.TrimSpace(number)
+}
+
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package firmafon
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var callbackBase = time.Date(2014, 3, 21, 13, 0, 0, 0, time.UTC)
+
+func testCallbackCall(uuid, direction, status, from, to string, minutes int) *Call {
+	return &Call{
+		CallUUID:   uuid,
+		Direction:  direction,
+		Status:     status,
+		FromNumber: from,
+		ToNumber:   to,
+		StartedAt:  callbackBase.Add(time.Duration(minutes) * time.Minute),
+	}
+}
+
+func TestCallbackQueue_Process(t *testing.T) {
+	q := NewCallbackQueue(nil)
+
+	calls := []*Call{
+		// processed out of order on purpose; Process sorts by StartedAt
+		testCallbackCall("4", CallDirectionOutgoing, CallStatusAnswered, "4571999999", "4512345678", 30),
+		testCallbackCall("1", CallDirectionIncoming, "missed", "4512345678", "4571999999", 0),
+		testCallbackCall("2", CallDirectionIncoming, "missed", "+45 12 34 56 78", "4571999999", 5),
+		testCallbackCall("3", CallDirectionOutgoing, "missed", "4571999999", "4512345678", 10),
+		testCallbackCall("5", CallDirectionIncoming, "missed", "4511223344", "4571999999", 40),
+		testCallbackCall("6", CallDirectionIncoming, "missed", "", "4571999999", 41),
+		testCallbackCall("7", CallDirectionIncoming, CallStatusAnswered, "4599887766", "4571999999", 42),
+	}
+	if err := q.Process(calls); err != nil {
+		t.Fatalf("Process returned error: %v", err)
+	}
+
+	resolved, err := q.store.Get("1")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	want := &Callback{
+		ID:            "1",
+		Number:        "+4512345678",
+		MissedCalls:   []string{"1", "2"},
+		FirstMissedAt: callbackBase,
+		LastMissedAt:  callbackBase.Add(5 * time.Minute),
+		Attempts:      []string{"3"},
+		State:         CallbackResolved,
+		Resolution:    CallbackCalledBack,
+		ResolvedAt:    callbackBase.Add(30 * time.Minute),
+		ResolvedBy:    "4",
+	}
+	if !reflect.DeepEqual(resolved, want) {
+		t.Errorf("Callback = %+v, want %+v", resolved, want)
+	}
+
+	pending, err := q.Pending()
+	if err != nil {
+		t.Fatalf("Pending returned error: %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != "5" {
+		t.Errorf("Pending returned %+v, want only callback 5", pending)
+	}
+
+	// replaying the same calls must not reopen or duplicate anything
+	if err := q.Process(calls); err != nil {
+		t.Fatalf("Process returned error: %v", err)
+	}
+	all, _ := q.store.List()
+	if len(all) != 2 {
+		t.Errorf("store has %d callbacks after replay, want 2", len(all))
+	}
+	again, _ := q.store.Get("1")
+	if !reflect.DeepEqual(again, want) {
+		t.Errorf("Callback after replay = %+v, want %+v", again, want)
+	}
+}
+
+func TestCallbackQueue_CalledIn(t *testing.T) {
+	q := NewCallbackQueue(nil)
+
+	ch := make(chan *Call, 3)
+	ch <- testCallbackCall("1", CallDirectionIncoming, "missed", "4512345678", "4571999999", 0)
+	ch <- testCallbackCall("2", CallDirectionIncoming, CallStatusAnswered, "4512345678", "4571999999", 10)
+	ch <- testCallbackCall("3", CallDirectionIncoming, "missed", "4512345678", "4571999999", 20)
+	close(ch)
+
+	if err := q.Consume(ch); err != nil {
+		t.Fatalf("Consume returned error: %v", err)
+	}
+
+	first, _ := q.store.Get("1")
+	if first.State != CallbackResolved || first.Resolution != CallbackCalledIn || first.ResolvedBy != "2" {
+		t.Errorf("Callback 1 = %+v, want resolved by call 2", first)
+	}
+
+	pending, _ := q.Pending()
+	if len(pending) != 1 || pending[0].ID != "3" {
+		t.Errorf("Pending returned %+v, want only callback 3", pending)
+	}
+}
+
+func TestCallbackQueue_AssignResolve(t *testing.T) {
+	q := NewCallbackQueue(NewMemoryCallbackStore())
+	now := callbackBase.Add(time.Hour)
+	q.now = func() time.Time { return now }
+
+	if err := q.ProcessCall(testCallbackCall("1", CallDirectionIncoming, "missed", "4512345678", "4571999999", 0)); err != nil {
+		t.Fatalf("ProcessCall returned error: %v", err)
+	}
+
+	cb, err := q.Assign("1", 2)
+	if err != nil {
+		t.Fatalf("Assign returned error: %v", err)
+	}
+	if cb.State != CallbackAssigned || cb.AssignedTo != 2 || !cb.AssignedAt.Equal(now) {
+		t.Errorf("Assign returned %+v", cb)
+	}
+
+	cb, err = q.Resolve("1")
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+	if cb.State != CallbackResolved || cb.Resolution != CallbackManual || !cb.ResolvedAt.Equal(now) {
+		t.Errorf("Resolve returned %+v", cb)
+	}
+
+	if _, err := q.Assign("1", 3); err != ErrCallbackResolved {
+		t.Errorf("Assign on resolved callback returned %v, want %v", err, ErrCallbackResolved)
+	}
+	if _, err := q.Resolve("1"); err != ErrCallbackResolved {
+		t.Errorf("Resolve on resolved callback returned %v, want %v", err, ErrCallbackResolved)
+	}
+	if _, err := q.Assign("nope", 3); err != ErrCallbackNotFound {
+		t.Errorf("Assign on unknown callback returned %v, want %v", err, ErrCallbackNotFound)
+	}
+}
+
+type failingCallbackStore struct {
+	*MemoryCallbackStore
+}
+
+func (failingCallbackStore) FindOpen(number string) (*Callback, error) {
+	return nil, errors.New("store unavailable")
+}
+
+func TestCallbackQueue_StoreError(t *testing.T) {
+	q := NewCallbackQueue(failingCallbackStore{NewMemoryCallbackStore()})
+
+	err := q.Process([]*Call{testCallbackCall("1", CallDirectionIncoming, "missed", "4512345678", "4571999999", 0)})
+	if err == nil {
+		t.Error("Process expected an error but got none")
+	}
+}
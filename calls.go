@@ -5,6 +5,14 @@ import (
 	"time"
 )
 
+// Call directions and statuses returned by the API.
+const (
+	CallDirectionIncoming = "incoming"
+	CallDirectionOutgoing = "outgoing"
+
+	CallStatusAnswered = "answered"
+)
+
 type CallsService struct {
 	*service
 	Endpoint string
@@ -57,6 +65,7 @@ func (s *CallsService) GetAll(opt *CallsListOptions) ([]*Call, *Response, error)
 	if err != nil {
 		return nil, nil, err
 	}
+	req = withOperation(req, "Calls", "GetAll")
 	calls := &firmafonCalls{}
 	resp, err := s.client.Do(req, &calls)
 	if err != nil {
@@ -72,6 +81,7 @@ func (s *CallsService) Get(uuid string) (*Call, *Response, error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	req = withOperation(req, "Calls", "Get")
 	call := &firmafonCall{}
 	resp, err := s.client.Do(req, &call)
 	if err != nil {
@@ -0,0 +1,208 @@
+package firmafon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrChecksumMismatch is returned when an archived recording does not match
+// the checksum computed while downloading it.
+var ErrChecksumMismatch = errors.New("firmafon: recording checksum mismatch")
+
+// recordingExtensions maps recording content types to file extensions.
+var recordingExtensions = map[string]string{
+	"audio/mpeg":  ".mp3",
+	"audio/mp3":   ".mp3",
+	"audio/wav":   ".wav",
+	"audio/x-wav": ".wav",
+	"audio/ogg":   ".ogg",
+}
+
+// ArchiveResult reports what RecordingArchiver.Archive did with each call.
+type ArchiveResult struct {
+	// Archived holds the UUIDs of the calls whose recordings were saved.
+	Archived []string
+
+	// Skipped holds the UUIDs of the calls whose recordings were already
+	// archived and passed verification.
+	Skipped []string
+
+	// Missing holds the UUIDs of the calls without a recording.
+	Missing []string
+
+	// Failed holds the error for each call whose recording could not be
+	// archived.
+	Failed map[string]error
+}
+
+// A RecordingArchiver saves call recordings to a directory. Each recording
+// is stored as <uuid><ext> next to <uuid>.sha256, a checksum file in the
+// format of sha256sum. Downloads go to <uuid>.part first and are resumed
+// with a range request if interrupted.
+type RecordingArchiver struct {
+	calls *CallsService
+	dir   string
+}
+
+// NewRecordingArchiver returns a RecordingArchiver saving the recordings of
+// calls listed with s to dir.
+func NewRecordingArchiver(s *CallsService, dir string) *RecordingArchiver {
+	return &RecordingArchiver{calls: s, dir: dir}
+}
+
+// Archive saves the recordings of the calls matching opt. opt may be nil.
+// Recordings that are already archived are verified against their checksum
+// file and downloaded again if they do not match. An error is returned only
+// if the calls cannot be listed or ctx is done; errors for single calls are
+// reported in the result.
+func (a *RecordingArchiver) Archive(ctx context.Context, opt *CallsListOptions) (*ArchiveResult, error) {
+	if err := os.MkdirAll(a.dir, 0755); err != nil {
+		return nil, err
+	}
+
+	calls, _, err := a.calls.GetAll(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ArchiveResult{Failed: make(map[string]error)}
+	for _, call := range calls {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		uuid := call.CallUUID
+		if err := a.Verify(uuid); err == nil {
+			result.Skipped = append(result.Skipped, uuid)
+			continue
+		}
+
+		err := a.ArchiveCall(ctx, uuid)
+		var errResp *ErrorResponse
+		switch {
+		case err == nil:
+			result.Archived = append(result.Archived, uuid)
+		case errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound:
+			result.Missing = append(result.Missing, uuid)
+		default:
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return result, ctxErr
+			}
+			result.Failed[uuid] = err
+		}
+	}
+
+	return result, nil
+}
+
+// ArchiveCall saves the recording of a single call, resuming a previous
+// partial download.
+func (a *RecordingArchiver) ArchiveCall(ctx context.Context, uuid string) error {
+	part := filepath.Join(a.dir, uuid+".part")
+	f, err := os.OpenFile(part, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// hash what is already on disk so the checksum covers the whole file
+	h := sha256.New()
+	offset, err := io.Copy(h, f)
+	if err != nil {
+		return err
+	}
+
+	rec, _, err := a.calls.ResumeRecording(ctx, uuid, io.MultiWriter(f, h), offset)
+	var errResp *ErrorResponse
+	switch {
+	case errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// the partial download does not fit the recording, start over next time
+		f.Close()
+		os.Remove(part)
+	case err != nil && offset == 0 && (rec == nil || rec.Written == 0):
+		// nothing was downloaded, e.g. the call has no recording
+		f.Close()
+		os.Remove(part)
+	}
+	if err != nil {
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if size := offset + rec.Written; rec.Size >= 0 && size != rec.Size {
+		return fmt.Errorf("firmafon: recording %s is %d bytes, want %d", uuid, size, rec.Size)
+	}
+
+	sum := hex.EncodeToString(h.Sum(nil))
+	if err := verifyFile(part, sum); err != nil {
+		os.Remove(part)
+		return err
+	}
+
+	name := uuid + recordingExtension(rec.ContentType)
+	if err := os.Rename(part, filepath.Join(a.dir, name)); err != nil {
+		return err
+	}
+
+	checksum := fmt.Sprintf("%s  %s\n", sum, name)
+	return os.WriteFile(filepath.Join(a.dir, uuid+".sha256"), []byte(checksum), 0644)
+}
+
+// Verify checks an archived recording against its checksum file. It returns
+// an error satisfying os.IsNotExist if the recording has not been archived
+// and ErrChecksumMismatch if the file has changed.
+func (a *RecordingArchiver) Verify(uuid string) error {
+	data, err := os.ReadFile(filepath.Join(a.dir, uuid+".sha256"))
+	if err != nil {
+		return err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) != 2 || filepath.Base(fields[1]) != fields[1] {
+		return fmt.Errorf("firmafon: invalid checksum file for recording %s", uuid)
+	}
+
+	return verifyFile(filepath.Join(a.dir, fields[1]), fields[0])
+}
+
+// verifyFile checks that the SHA-256 checksum of the file at path is sum.
+func verifyFile(path, sum string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	if hex.EncodeToString(h.Sum(nil)) != sum {
+		return fmt.Errorf("%w: %s", ErrChecksumMismatch, path)
+	}
+
+	return nil
+}
+
+// recordingExtension returns the file extension for a recording content type.
+func recordingExtension(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	return recordingExtensions[mediaType]
+}
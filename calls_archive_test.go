@@ -0,0 +1,148 @@
+package firmafon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRecordingArchiver_Archive(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/calls", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"calls": [{"call_uuid": "a"}, {"call_uuid": "b"}, {"call_uuid": "c"}]}`)
+	})
+	downloads := 0
+	mux.HandleFunc("/calls/a/recording", func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		testRecordingHandler(t, nil)(w, r)
+	})
+	mux.HandleFunc("/calls/b/recording", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+	mux.HandleFunc("/calls/c/recording", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	})
+
+	dir := t.TempDir()
+	a := NewRecordingArchiver(client.Calls, dir)
+
+	result, err := a.Archive(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Archive returned error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Archived, []string{"a"}) || !reflect.DeepEqual(result.Missing, []string{"b"}) {
+		t.Errorf("Archive returned %+v, want a archived and b missing", result)
+	}
+	if _, ok := result.Failed["c"]; !ok || len(result.Failed) != 1 {
+		t.Errorf("Failed = %v, want c", result.Failed)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "a.mp3"))
+	if err != nil || !bytes.Equal(data, testRecording) {
+		t.Errorf("archived recording = %d bytes, %v; want the recording", len(data), err)
+	}
+	if err := a.Verify("a"); err != nil {
+		t.Errorf("Verify returned error: %v", err)
+	}
+
+	// archived recordings are skipped on the next run
+	result, err = a.Archive(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Archive returned error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Skipped, []string{"a"}) || downloads != 1 {
+		t.Errorf("second Archive returned %+v after %d downloads, want a skipped", result, downloads)
+	}
+
+	// a modified recording fails verification and is downloaded again
+	os.WriteFile(filepath.Join(dir, "a.mp3"), []byte("tampered"), 0644)
+	if err := a.Verify("a"); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Verify returned %v, want ErrChecksumMismatch", err)
+	}
+	result, _ = a.Archive(context.Background(), nil)
+	if !reflect.DeepEqual(result.Archived, []string{"a"}) || downloads != 2 {
+		t.Errorf("third Archive returned %+v after %d downloads, want a archived again", result, downloads)
+	}
+
+	if err := a.Verify("b"); !os.IsNotExist(err) {
+		t.Errorf("Verify of a missing recording returned %v, want a not exist error", err)
+	}
+
+	// failed downloads that got no data leave no part files behind
+	for _, uuid := range []string{"b", "c"} {
+		if _, err := os.Stat(filepath.Join(dir, uuid+".part")); !os.IsNotExist(err) {
+			t.Errorf("%s.part exists after a failed download, err = %v", uuid, err)
+		}
+	}
+}
+
+func TestRecordingArchiver_ArchiveCall_resume(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var ranges []string
+	mux.HandleFunc("/calls/a/recording", testRecordingHandler(t, &ranges))
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "a.part"), testRecording[:500], 0644)
+
+	a := NewRecordingArchiver(client.Calls, dir)
+	if err := a.ArchiveCall(context.Background(), "a"); err != nil {
+		t.Fatalf("ArchiveCall returned error: %v", err)
+	}
+
+	if want := []string{"bytes=500-"}; !reflect.DeepEqual(ranges, want) {
+		t.Errorf("Range headers = %q, want %q", ranges, want)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "a.mp3"))
+	if !bytes.Equal(data, testRecording) {
+		t.Errorf("resumed recording is %d bytes, want the %d byte recording", len(data), len(testRecording))
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a.part")); !os.IsNotExist(err) {
+		t.Errorf("partial download was not removed: %v", err)
+	}
+	if err := a.Verify("a"); err != nil {
+		t.Errorf("Verify returned error: %v", err)
+	}
+}
+
+func TestRecordingArchiver_ArchiveCall_badPart(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/calls/a/recording", testRecordingHandler(t, nil))
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "a.part"), bytes.Repeat([]byte("x"), len(testRecording)+10), 0644)
+
+	a := NewRecordingArchiver(client.Calls, dir)
+	if err := a.ArchiveCall(context.Background(), "a"); err == nil {
+		t.Fatal("ArchiveCall expected an error for a partial download larger than the recording")
+	}
+	if err := a.ArchiveCall(context.Background(), "a"); err != nil {
+		t.Errorf("ArchiveCall after discarding the partial download returned error: %v", err)
+	}
+}
+
+func TestRecordingExtension(t *testing.T) {
+	tests := map[string]string{
+		"audio/mpeg":            ".mp3",
+		"audio/wav; codecs=1":   ".wav",
+		"application/x-unknown": "",
+		"":                      "",
+	}
+
+	for in, want := range tests {
+		if got := recordingExtension(in); got != want {
+			t.Errorf("recordingExtension(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
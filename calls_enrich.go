@@ -0,0 +1,207 @@
+package firmafon
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Endpoint types seen in Call.Endpoint and CompanyNumber.Endpoint.
+const (
+	EndpointEmployee      = "Employee"
+	EndpointEmployeeGroup = "EmployeeGroup"
+	EndpointReception     = "Reception"
+	EndpointIVR           = "Ivr"
+)
+
+// DefaultEnrichmentTTL is how long a CallEnricher keeps the employee list
+// before fetching it again.
+const DefaultEnrichmentTTL = 5 * time.Minute
+
+// Endpoint is a parsed Call.Endpoint such as "Reception#1".
+type Endpoint struct {
+	Type string
+	ID   int
+}
+
+// ParseEndpoint parses an endpoint of the form "Type#ID". It reports false if
+// s is not in that form.
+func ParseEndpoint(s string) (Endpoint, bool) {
+	i := strings.LastIndex(s, "#")
+	if i <= 0 {
+		return Endpoint{}, false
+	}
+
+	id, err := strconv.Atoi(s[i+1:])
+	if err != nil {
+		return Endpoint{}, false
+	}
+
+	return Endpoint{Type: s[:i], ID: id}, true
+}
+
+// String returns the endpoint in the form "Type#ID", or just the type if it
+// has no ID.
+func (e Endpoint) String() string {
+	if e.ID == 0 {
+		return e.Type
+	}
+	return e.Type + "#" + strconv.Itoa(e.ID)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (e Endpoint) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Text that is not of the
+// form "Type#ID" is kept as the type.
+func (e *Endpoint) UnmarshalText(text []byte) error {
+	if p, ok := ParseEndpoint(string(text)); ok {
+		*e = p
+	} else {
+		*e = Endpoint{Type: string(text)}
+	}
+	return nil
+}
+
+// EmployeeEndpoint returns the endpoint of an employee.
+func EmployeeEndpoint(id int) Endpoint {
+	return Endpoint{Type: EndpointEmployee, ID: id}
+}
+
+// EmployeeGroupEndpoint returns the endpoint of an employee group.
+func EmployeeGroupEndpoint(id int) Endpoint {
+	return Endpoint{Type: EndpointEmployeeGroup, ID: id}
+}
+
+// ReceptionEndpoint returns the endpoint of a reception.
+func ReceptionEndpoint(id int) Endpoint {
+	return Endpoint{Type: EndpointReception, ID: id}
+}
+
+// IVREndpoint returns the endpoint of an IVR menu.
+func IVREndpoint(id int) Endpoint {
+	return Endpoint{Type: EndpointIVR, ID: id}
+}
+
+// EnrichedCall is a Call with its employee references resolved.
+type EnrichedCall struct {
+	*Call
+
+	// Employee is the employee who answered the call, if known.
+	Employee *Employee `json:"employee,omitempty"`
+
+	// Groups holds the employee group IDs of Employee.
+	Groups []int `json:"groups,omitempty"`
+
+	// ParsedEndpoint is the parsed Call.Endpoint.
+	ParsedEndpoint Endpoint `json:"parsed_endpoint"`
+
+	// EndpointOwner is the employee owning the endpoint when the endpoint
+	// belongs to an employee.
+	EndpointOwner *Employee `json:"endpoint_owner,omitempty"`
+}
+
+// A CallEnricher resolves the employees referenced by calls using
+// EmployeesService.All. The employee list is cached and fetched again once it
+// is older than the TTL. It is safe for concurrent use.
+type CallEnricher struct {
+	employees *EmployeesService
+	ttl       time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	fetched  time.Time
+	byID     map[int]*Employee
+	byNumber map[string]*Employee
+}
+
+// NewCallEnricher returns a CallEnricher looking up employees with s. If ttl
+// is zero DefaultEnrichmentTTL is used.
+func NewCallEnricher(s *EmployeesService, ttl time.Duration) *CallEnricher {
+	if ttl == 0 {
+		ttl = DefaultEnrichmentTTL
+	}
+
+	return &CallEnricher{employees: s, ttl: ttl, now: time.Now}
+}
+
+// Refresh fetches the employee list regardless of the TTL.
+func (e *CallEnricher) Refresh() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.refresh()
+}
+
+func (e *CallEnricher) refresh() error {
+	emps, _, err := e.employees.All()
+	if err != nil {
+		return err
+	}
+
+	e.byID = make(map[int]*Employee, len(emps))
+	e.byNumber = make(map[string]*Employee, len(emps))
+	for _, emp := range emps {
+		e.byID[emp.ID] = emp
+		if n := PhoneNumber(emp.Number).E164(); n != "" {
+			e.byNumber[n] = emp
+		}
+	}
+	e.fetched = e.now()
+
+	return nil
+}
+
+// Enrich resolves the employees referenced by c.
+func (e *CallEnricher) Enrich(c *Call) (*EnrichedCall, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.byID == nil || e.now().Sub(e.fetched) >= e.ttl {
+		if err := e.refresh(); err != nil {
+			return nil, err
+		}
+	}
+
+	return e.enrich(c), nil
+}
+
+// EnrichAll resolves the employees referenced by calls.
+func (e *CallEnricher) EnrichAll(calls []*Call) ([]*EnrichedCall, error) {
+	enriched := make([]*EnrichedCall, len(calls))
+	for i, c := range calls {
+		ec, err := e.Enrich(c)
+		if err != nil {
+			return nil, err
+		}
+		enriched[i] = ec
+	}
+
+	return enriched, nil
+}
+
+func (e *CallEnricher) enrich(c *Call) *EnrichedCall {
+	ec := &EnrichedCall{Call: c}
+
+	if ab := c.AnsweredBy; ab != nil {
+		ec.Employee = e.byID[ab.ID]
+		if ec.Employee == nil {
+			ec.Employee = e.byNumber[PhoneNumber(ab.Number).E164()]
+		}
+		if ec.Employee != nil {
+			ec.Groups = ec.Employee.EmployeeGroupIds
+		}
+	}
+
+	if ep, ok := ParseEndpoint(c.Endpoint); ok {
+		ec.ParsedEndpoint = ep
+		if ep.Type == EndpointEmployee {
+			ec.EndpointOwner = e.byID[ep.ID]
+		}
+	}
+
+	return ec
+}
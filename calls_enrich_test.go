@@ -0,0 +1,170 @@
+package firmafon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   Endpoint
+		wantOK bool
+	}{
+		{"Reception#1", Endpoint{Type: EndpointReception, ID: 1}, true},
+		{"Employee#42", Endpoint{Type: EndpointEmployee, ID: 42}, true},
+		{"Employee#", Endpoint{}, false},
+		{"#1", Endpoint{}, false},
+		{"4512345678", Endpoint{}, false},
+	}
+
+	for _, test := range tests {
+		got, ok := ParseEndpoint(test.in)
+		if got != test.want || ok != test.wantOK {
+			t.Errorf("ParseEndpoint(%q) = %v, %v, want %v, %v", test.in, got, ok, test.want, test.wantOK)
+		}
+	}
+}
+
+func TestCallEnricher_Enrich(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/employees", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		requests++
+		fmt.Fprint(w, `{"employees":[
+			{"id": 2, "name": "Karsten Kollega", "number": "4587654321", "employee_group_ids": [1, 3]},
+			{"id": 3, "name": "Rikke Reception", "number": "4511111111"}
+		]}`)
+	})
+
+	e := NewCallEnricher(client.Employees, time.Minute)
+	now := time.Date(2014, 3, 21, 13, 0, 0, 0, time.UTC)
+	e.now = func() time.Time { return now }
+
+	calls := []*Call{
+		{CallUUID: "1", Endpoint: "Employee#3", AnsweredBy: &CallAnsweredBy{ID: 2}},
+		// unknown ID, but the number matches an employee
+		{CallUUID: "2", Endpoint: "Reception#1", AnsweredBy: &CallAnsweredBy{ID: 99, Number: "+45 87 65 43 21"}},
+		{CallUUID: "3", Endpoint: "Reception#1"},
+	}
+	enriched, err := e.EnrichAll(calls)
+	if err != nil {
+		t.Fatalf("EnrichAll returned error: %v", err)
+	}
+
+	karsten := &Employee{ID: 2, Name: "Karsten Kollega", Number: "4587654321", EmployeeGroupIds: []int{1, 3}}
+	rikke := &Employee{ID: 3, Name: "Rikke Reception", Number: "4511111111"}
+	want := []*EnrichedCall{
+		{Call: calls[0], Employee: karsten, Groups: []int{1, 3}, ParsedEndpoint: Endpoint{EndpointEmployee, 3}, EndpointOwner: rikke},
+		{Call: calls[1], Employee: karsten, Groups: []int{1, 3}, ParsedEndpoint: Endpoint{EndpointReception, 1}},
+		{Call: calls[2], ParsedEndpoint: Endpoint{EndpointReception, 1}},
+	}
+	if !reflect.DeepEqual(enriched, want) {
+		t.Errorf("EnrichAll returned %+v, want %+v", enriched, want)
+	}
+	if requests != 1 {
+		t.Errorf("employees were fetched %d times, want 1", requests)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := e.Enrich(calls[0]); err != nil {
+		t.Fatalf("Enrich returned error: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("employees were fetched %d times after the TTL expired, want 2", requests)
+	}
+
+	if err := e.Refresh(); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("employees were fetched %d times after Refresh, want 3", requests)
+	}
+}
+
+func TestCallEnricher_Enrich_Error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/employees", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	})
+
+	e := NewCallEnricher(client.Employees, 0)
+	if _, err := e.EnrichAll([]*Call{{CallUUID: "1"}}); err == nil {
+		t.Error("EnrichAll expected an error but got none")
+	}
+}
+
+func TestEndpoint_text(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Endpoint
+	}{
+		{`"Reception#1"`, ReceptionEndpoint(1)},
+		{`"Ivr#12"`, IVREndpoint(12)},
+		{`"Voicemail"`, Endpoint{Type: "Voicemail"}},
+		{`""`, Endpoint{}},
+	}
+
+	for _, test := range tests {
+		var got Endpoint
+		if err := json.Unmarshal([]byte(test.in), &got); err != nil {
+			t.Fatalf("Unmarshal(%s) returned error: %v", test.in, err)
+		}
+		if got != test.want {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", test.in, got, test.want)
+		}
+
+		data, _ := json.Marshal(got)
+		if string(data) != test.in {
+			t.Errorf("Marshal(%+v) = %s, want %s", got, data, test.in)
+		}
+	}
+}
+
+func TestEnrichedCall_JSON(t *testing.T) {
+	c := &EnrichedCall{
+		Call:           &Call{CallUUID: "1", Endpoint: "Employee#3"},
+		Groups:         []int{1},
+		ParsedEndpoint: EmployeeEndpoint(3),
+		EndpointOwner:  &Employee{ID: 3},
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+
+	if got["endpoint"] != "Employee#3" {
+		t.Errorf("endpoint = %v, want the Call.Endpoint %q", got["endpoint"], "Employee#3")
+	}
+	if got["parsed_endpoint"] != "Employee#3" {
+		t.Errorf("parsed_endpoint = %v, want %q", got["parsed_endpoint"], "Employee#3")
+	}
+	if !reflect.DeepEqual(got["groups"], []interface{}{1.0}) {
+		t.Errorf("groups = %v, want [1]", got["groups"])
+	}
+	if _, ok := got["endpoint_owner"]; !ok {
+		t.Error("endpoint_owner is missing")
+	}
+	if _, ok := got["employee"]; ok {
+		t.Error("employee is set for a call without one")
+	}
+	for _, k := range []string{"Employee", "Groups", "Endpoint", "ParsedEndpoint", "EndpointOwner"} {
+		if _, ok := got[k]; ok {
+			t.Errorf("field %s is not snake_case", k)
+		}
+	}
+}
@@ -0,0 +1,381 @@
+package firmafon
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// CallColumn identifies a single column in a call export.
+type CallColumn string
+
+// Columns available for call exports. The nested FromContact and AnsweredBy
+// objects are flattened into their own columns.
+const (
+	CallColumnUUID              CallColumn = "call_uuid"
+	CallColumnCompanyID         CallColumn = "company_id"
+	CallColumnEndpoint          CallColumn = "endpoint"
+	CallColumnFromNumber        CallColumn = "from_number"
+	CallColumnToNumber          CallColumn = "to_number"
+	CallColumnFromContactID     CallColumn = "from_contact_id"
+	CallColumnFromContactName   CallColumn = "from_contact_name"
+	CallColumnFromContactNumber CallColumn = "from_contact_number"
+	CallColumnFromContactEmail  CallColumn = "from_contact_email"
+	CallColumnDirection         CallColumn = "direction"
+	CallColumnStatus            CallColumn = "status"
+	CallColumnStartedAt         CallColumn = "started_at"
+	CallColumnAnsweredAt        CallColumn = "answered_at"
+	CallColumnEndedAt           CallColumn = "ended_at"
+	CallColumnDuration          CallColumn = "duration"
+	CallColumnAnsweredByID      CallColumn = "answered_by_id"
+	CallColumnAnsweredByName    CallColumn = "answered_by_name"
+	CallColumnAnsweredByNumber  CallColumn = "answered_by_number"
+)
+
+// DefaultCallColumns is the column set used when CallCSVOptions.Columns is empty.
+var DefaultCallColumns = []CallColumn{
+	CallColumnUUID,
+	CallColumnStartedAt,
+	CallColumnAnsweredAt,
+	CallColumnEndedAt,
+	CallColumnDuration,
+	CallColumnDirection,
+	CallColumnStatus,
+	CallColumnEndpoint,
+	CallColumnFromNumber,
+	CallColumnFromContactName,
+	CallColumnToNumber,
+	CallColumnAnsweredByName,
+	CallColumnAnsweredByNumber,
+}
+
+const (
+	// DefaultCSVComma is the field delimiter used by Danish Excel installations.
+	DefaultCSVComma = ';'
+
+	// DefaultCSVTimeLayout is a timestamp layout Danish Excel recognizes as a date.
+	DefaultCSVTimeLayout = "02-01-2006 15:04:05"
+)
+
+// utf8BOM makes Excel detect the encoding of the file, which is needed for
+// names containing æ, ø and å to display correctly.
+const utf8BOM = "\ufeff"
+
+// CallWriter is implemented by the call exporters so a stream of calls can be
+// written without caring about the output format.
+type CallWriter interface {
+	Write(c *Call) error
+	Flush() error
+}
+
+// CallCSVOptions specifies the optional parameters to NewCallCSVWriter.
+type CallCSVOptions struct {
+	// Columns to write, in order. Defaults to DefaultCallColumns.
+	Columns []CallColumn
+
+	// Comma is the field delimiter. Defaults to DefaultCSVComma.
+	Comma rune
+
+	// TimeLayout is used to format timestamps. Defaults to DefaultCSVTimeLayout.
+	TimeLayout string
+
+	// Location timestamps are converted to before formatting. Defaults to time.Local.
+	Location *time.Location
+
+	// OmitHeader disables the header row.
+	OmitHeader bool
+
+	// ByteOrderMark prefixes the output with a UTF-8 byte order mark.
+	ByteOrderMark bool
+}
+
+// CallCSVWriter writes calls as CSV records.
+type CallCSVWriter struct {
+	w             *csv.Writer
+	out           io.Writer
+	columns       []CallColumn
+	layout        string
+	loc           *time.Location
+	header        bool
+	bom           bool
+	headerWritten bool
+}
+
+// NewCallCSVWriter returns a CallCSVWriter writing to w. opt may be nil.
+// It returns an error if opt references an unknown column.
+func NewCallCSVWriter(w io.Writer, opt *CallCSVOptions) (*CallCSVWriter, error) {
+	if opt == nil {
+		opt = &CallCSVOptions{}
+	}
+
+	cw := &CallCSVWriter{
+		w:       csv.NewWriter(w),
+		out:     w,
+		columns: opt.Columns,
+		layout:  opt.TimeLayout,
+		loc:     opt.Location,
+		header:  !opt.OmitHeader,
+		bom:     opt.ByteOrderMark,
+	}
+	cw.w.Comma = DefaultCSVComma
+	if opt.Comma != 0 {
+		cw.w.Comma = opt.Comma
+	}
+	if len(cw.columns) == 0 {
+		cw.columns = DefaultCallColumns
+	}
+	if cw.layout == "" {
+		cw.layout = DefaultCSVTimeLayout
+	}
+	if cw.loc == nil {
+		cw.loc = time.Local
+	}
+
+	for _, col := range cw.columns {
+		if _, ok := callColumnValues[col]; !ok {
+			return nil, fmt.Errorf("unknown call column %q", col)
+		}
+	}
+
+	return cw, nil
+}
+
+// Write writes a single call. The header row is written before the first call.
+func (cw *CallCSVWriter) Write(c *Call) error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+
+	record := make([]string, len(cw.columns))
+	for i, col := range cw.columns {
+		record[i] = callColumnValues[col](cw, c)
+	}
+
+	return cw.w.Write(record)
+}
+
+// WriteAll writes all calls and flushes the output.
+func (cw *CallCSVWriter) WriteAll(calls []*Call) error {
+	for _, c := range calls {
+		if err := cw.Write(c); err != nil {
+			return err
+		}
+	}
+
+	return cw.Flush()
+}
+
+// Flush writes any buffered data to the underlying io.Writer. The header row
+// is written even if no calls were written.
+func (cw *CallCSVWriter) Flush() error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+	cw.w.Flush()
+
+	return cw.w.Error()
+}
+
+func (cw *CallCSVWriter) writeHeader() error {
+	if cw.headerWritten {
+		return nil
+	}
+	cw.headerWritten = true
+
+	if cw.bom {
+		if _, err := io.WriteString(cw.out, utf8BOM); err != nil {
+			return err
+		}
+	}
+	if !cw.header {
+		return nil
+	}
+
+	header := make([]string, len(cw.columns))
+	for i, col := range cw.columns {
+		header[i] = string(col)
+	}
+
+	return cw.w.Write(header)
+}
+
+func (cw *CallCSVWriter) formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.In(cw.loc).Format(cw.layout)
+}
+
+func formatInt(i int) string {
+	if i == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(i)
+}
+
+// callDuration returns the talk time of the call, which is zero if the call
+// was not answered.
+func callDuration(c *Call) time.Duration {
+	if c.AnsweredAt.IsZero() || c.EndedAt.Before(c.AnsweredAt) {
+		return 0
+	}
+
+	return c.EndedAt.Sub(c.AnsweredAt)
+}
+
+var callColumnValues = map[CallColumn]func(cw *CallCSVWriter, c *Call) string{
+	CallColumnUUID:       func(_ *CallCSVWriter, c *Call) string { return c.CallUUID },
+	CallColumnCompanyID:  func(_ *CallCSVWriter, c *Call) string { return formatInt(c.CompanyID) },
+	CallColumnEndpoint:   func(_ *CallCSVWriter, c *Call) string { return c.Endpoint },
+	CallColumnFromNumber: func(_ *CallCSVWriter, c *Call) string { return c.FromNumber },
+	CallColumnToNumber:   func(_ *CallCSVWriter, c *Call) string { return c.ToNumber },
+	CallColumnFromContactID: func(_ *CallCSVWriter, c *Call) string {
+		if c.FromContact == nil {
+			return ""
+		}
+		return formatInt(c.FromContact.ID)
+	},
+	CallColumnFromContactName: func(_ *CallCSVWriter, c *Call) string {
+		if c.FromContact == nil {
+			return ""
+		}
+		return c.FromContact.Name
+	},
+	CallColumnFromContactNumber: func(_ *CallCSVWriter, c *Call) string {
+		if c.FromContact == nil {
+			return ""
+		}
+		return c.FromContact.Number
+	},
+	CallColumnFromContactEmail: func(_ *CallCSVWriter, c *Call) string {
+		if c.FromContact == nil {
+			return ""
+		}
+		return c.FromContact.Email
+	},
+	CallColumnDirection:  func(_ *CallCSVWriter, c *Call) string { return c.Direction },
+	CallColumnStatus:     func(_ *CallCSVWriter, c *Call) string { return c.Status },
+	CallColumnStartedAt:  func(cw *CallCSVWriter, c *Call) string { return cw.formatTime(c.StartedAt) },
+	CallColumnAnsweredAt: func(cw *CallCSVWriter, c *Call) string { return cw.formatTime(c.AnsweredAt) },
+	CallColumnEndedAt:    func(cw *CallCSVWriter, c *Call) string { return cw.formatTime(c.EndedAt) },
+	CallColumnDuration: func(_ *CallCSVWriter, c *Call) string {
+		return strconv.Itoa(int(callDuration(c) / time.Second))
+	},
+	CallColumnAnsweredByID: func(_ *CallCSVWriter, c *Call) string {
+		if c.AnsweredBy == nil {
+			return ""
+		}
+		return formatInt(c.AnsweredBy.ID)
+	},
+	CallColumnAnsweredByName: func(_ *CallCSVWriter, c *Call) string {
+		if c.AnsweredBy == nil {
+			return ""
+		}
+		return c.AnsweredBy.Name
+	},
+	CallColumnAnsweredByNumber: func(_ *CallCSVWriter, c *Call) string {
+		if c.AnsweredBy == nil {
+			return ""
+		}
+		return c.AnsweredBy.Number
+	},
+}
+
+// flatCall is the JSON Lines representation of a call.
+type flatCall struct {
+	CallUUID          string     `json:"call_uuid"`
+	CompanyID         int        `json:"company_id"`
+	Endpoint          string     `json:"endpoint"`
+	FromNumber        string     `json:"from_number"`
+	ToNumber          string     `json:"to_number"`
+	FromContactID     int        `json:"from_contact_id,omitempty"`
+	FromContactName   string     `json:"from_contact_name,omitempty"`
+	FromContactNumber string     `json:"from_contact_number,omitempty"`
+	FromContactEmail  string     `json:"from_contact_email,omitempty"`
+	Direction         string     `json:"direction"`
+	Status            string     `json:"status"`
+	StartedAt         *time.Time `json:"started_at,omitempty"`
+	AnsweredAt        *time.Time `json:"answered_at,omitempty"`
+	EndedAt           *time.Time `json:"ended_at,omitempty"`
+	Duration          int        `json:"duration"`
+	AnsweredByID      int        `json:"answered_by_id,omitempty"`
+	AnsweredByName    string     `json:"answered_by_name,omitempty"`
+	AnsweredByNumber  string     `json:"answered_by_number,omitempty"`
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+func newFlatCall(c *Call) *flatCall {
+	f := &flatCall{
+		CallUUID:   c.CallUUID,
+		CompanyID:  c.CompanyID,
+		Endpoint:   c.Endpoint,
+		FromNumber: c.FromNumber,
+		ToNumber:   c.ToNumber,
+		Direction:  c.Direction,
+		Status:     c.Status,
+		StartedAt:  timePtr(c.StartedAt),
+		AnsweredAt: timePtr(c.AnsweredAt),
+		EndedAt:    timePtr(c.EndedAt),
+		Duration:   int(callDuration(c) / time.Second),
+	}
+	if fc := c.FromContact; fc != nil {
+		f.FromContactID = fc.ID
+		f.FromContactName = fc.Name
+		f.FromContactNumber = fc.Number
+		f.FromContactEmail = fc.Email
+	}
+	if ab := c.AnsweredBy; ab != nil {
+		f.AnsweredByID = ab.ID
+		f.AnsweredByName = ab.Name
+		f.AnsweredByNumber = ab.Number
+	}
+
+	return f
+}
+
+// CallJSONLinesWriter writes calls as flattened JSON objects, one per line.
+type CallJSONLinesWriter struct {
+	enc *json.Encoder
+}
+
+// NewCallJSONLinesWriter returns a CallJSONLinesWriter writing to w.
+func NewCallJSONLinesWriter(w io.Writer) *CallJSONLinesWriter {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	return &CallJSONLinesWriter{enc: enc}
+}
+
+// Write writes a single call.
+func (jw *CallJSONLinesWriter) Write(c *Call) error {
+	return jw.enc.Encode(newFlatCall(c))
+}
+
+// WriteAll writes all calls.
+func (jw *CallJSONLinesWriter) WriteAll(calls []*Call) error {
+	for _, c := range calls {
+		if err := jw.Write(c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Flush is a no-op; every call is written to the underlying io.Writer as soon
+// as it is encoded.
+func (jw *CallJSONLinesWriter) Flush() error {
+	return nil
+}
@@ -0,0 +1,164 @@
+package firmafon
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testExportCalls() []*Call {
+	started, _ := time.Parse(time.RFC3339, "2014-03-21T13:59:04Z")
+	answered, _ := time.Parse(time.RFC3339, "2014-03-21T13:59:07Z")
+	ended, _ := time.Parse(time.RFC3339, "2014-03-21T13:59:59Z")
+
+	return []*Call{
+		{
+			CallUUID:   "e54f5820-386d-0132-5bc3-14dae9edd21d",
+			CompanyID:  1,
+			Endpoint:   "Reception#1",
+			FromNumber: "4512345678",
+			ToNumber:   "4571999999",
+			FromContact: &CallFromContact{
+				ID:     1,
+				Number: "4512345678",
+				Name:   "Kim Kontakt; Jensen",
+				Email:  "kimkontakt@example.com",
+			},
+			Direction:  "incoming",
+			StartedAt:  started,
+			AnsweredAt: answered,
+			AnsweredBy: &CallAnsweredBy{
+				ID:     2,
+				Name:   "Karsten Kollega",
+				Number: "4587654321",
+			},
+			EndedAt: ended,
+			Status:  "answered",
+		},
+		{
+			CallUUID:   "f12a4820-386d-0132-5bc3-14dae9edd21d",
+			CompanyID:  1,
+			Endpoint:   "Reception#1",
+			FromNumber: "4511223344",
+			ToNumber:   "4571999999",
+			Direction:  "incoming",
+			StartedAt:  started,
+			EndedAt:    started.Add(20 * time.Second),
+			Status:     "missed",
+		},
+	}
+}
+
+func TestCallCSVWriter_WriteAll(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewCallCSVWriter(&buf, &CallCSVOptions{Location: time.UTC})
+	if err != nil {
+		t.Fatalf("NewCallCSVWriter returned error: %v", err)
+	}
+
+	if err := w.WriteAll(testExportCalls()); err != nil {
+		t.Fatalf("WriteAll returned error: %v", err)
+	}
+
+	want := "call_uuid;started_at;answered_at;ended_at;duration;direction;status;endpoint;from_number;from_contact_name;to_number;answered_by_name;answered_by_number\n" +
+		"e54f5820-386d-0132-5bc3-14dae9edd21d;21-03-2014 13:59:04;21-03-2014 13:59:07;21-03-2014 13:59:59;52;incoming;answered;Reception#1;4512345678;\"Kim Kontakt; Jensen\";4571999999;Karsten Kollega;4587654321\n" +
+		"f12a4820-386d-0132-5bc3-14dae9edd21d;21-03-2014 13:59:04;;21-03-2014 13:59:24;0;incoming;missed;Reception#1;4511223344;;4571999999;;\n"
+	if got := buf.String(); got != want {
+		t.Errorf("CSV export returned\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCallCSVWriter_Options(t *testing.T) {
+	var buf bytes.Buffer
+	loc := time.FixedZone("CET", 3600)
+	w, err := NewCallCSVWriter(&buf, &CallCSVOptions{
+		Columns:       []CallColumn{CallColumnUUID, CallColumnStartedAt, CallColumnFromContactEmail, CallColumnAnsweredByID},
+		Comma:         ',',
+		TimeLayout:    time.RFC3339,
+		Location:      loc,
+		OmitHeader:    true,
+		ByteOrderMark: true,
+	})
+	if err != nil {
+		t.Fatalf("NewCallCSVWriter returned error: %v", err)
+	}
+
+	if err := w.Write(testExportCalls()[0]); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+
+	want := utf8BOM + "e54f5820-386d-0132-5bc3-14dae9edd21d,2014-03-21T14:59:04+01:00,kimkontakt@example.com,2\n"
+	if got := buf.String(); got != want {
+		t.Errorf("CSV export returned %q, want %q", got, want)
+	}
+}
+
+func TestCallCSVWriter_EmptyFlush(t *testing.T) {
+	var buf bytes.Buffer
+	w, _ := NewCallCSVWriter(&buf, &CallCSVOptions{Columns: []CallColumn{CallColumnUUID, CallColumnStatus}})
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+
+	if got, want := buf.String(), "call_uuid;status\n"; got != want {
+		t.Errorf("CSV export returned %q, want %q", got, want)
+	}
+}
+
+func TestNewCallCSVWriter_UnknownColumn(t *testing.T) {
+	_, err := NewCallCSVWriter(&bytes.Buffer{}, &CallCSVOptions{Columns: []CallColumn{"nope"}})
+	if err == nil {
+		t.Error("NewCallCSVWriter expected an error but got none")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) { return 0, errors.New("write failed") }
+
+func TestCallCSVWriter_WriteError(t *testing.T) {
+	w, _ := NewCallCSVWriter(failingWriter{}, nil)
+	if err := w.WriteAll(testExportCalls()); err == nil {
+		t.Error("WriteAll expected an error but got none")
+	}
+}
+
+func TestCallJSONLinesWriter_WriteAll(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCallJSONLinesWriter(&buf)
+	if err := w.WriteAll(testExportCalls()); err != nil {
+		t.Fatalf("WriteAll returned error: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+
+	want := []string{
+		`{"call_uuid":"e54f5820-386d-0132-5bc3-14dae9edd21d","company_id":1,"endpoint":"Reception#1","from_number":"4512345678","to_number":"4571999999","from_contact_id":1,"from_contact_name":"Kim Kontakt; Jensen","from_contact_number":"4512345678","from_contact_email":"kimkontakt@example.com","direction":"incoming","status":"answered","started_at":"2014-03-21T13:59:04Z","answered_at":"2014-03-21T13:59:07Z","ended_at":"2014-03-21T13:59:59Z","duration":52,"answered_by_id":2,"answered_by_name":"Karsten Kollega","answered_by_number":"4587654321"}`,
+		`{"call_uuid":"f12a4820-386d-0132-5bc3-14dae9edd21d","company_id":1,"endpoint":"Reception#1","from_number":"4511223344","to_number":"4571999999","direction":"incoming","status":"missed","started_at":"2014-03-21T13:59:04Z","ended_at":"2014-03-21T13:59:24Z","duration":0}`,
+	}
+	got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("JSON Lines export returned\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestCallWriter_Interface(t *testing.T) {
+	csvWriter, _ := NewCallCSVWriter(&bytes.Buffer{}, nil)
+	for _, w := range []CallWriter{csvWriter, NewCallJSONLinesWriter(&bytes.Buffer{})} {
+		for _, c := range testExportCalls() {
+			if err := w.Write(c); err != nil {
+				t.Errorf("%T.Write returned error: %v", w, err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			t.Errorf("%T.Flush returned error: %v", w, err)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package firmafon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Recording describes a call recording downloaded with
+// CallsService.Recording or CallsService.ResumeRecording.
+type Recording struct {
+	// ContentType is the audio format, e.g. "audio/mpeg".
+	ContentType string
+
+	// Size is the total size of the recording in bytes, or -1 if the API
+	// did not report it.
+	Size int64
+
+	// Written is the number of bytes written to the writer by the call.
+	Written int64
+}
+
+// Recording streams the recording of a call to w without buffering it in
+// memory. Recordings are never stored in Client.HTTPCache.
+func (s *CallsService) Recording(ctx context.Context, uuid string, w io.Writer) (*Recording, *Response, error) {
+	return s.recording(ctx, uuid, w, 0, "Recording")
+}
+
+// ResumeRecording streams the recording of a call to w starting at offset,
+// e.g. the size of a partially downloaded file. An HTTP range request is
+// used, and if the API ignores it the first offset bytes are discarded, so w
+// always receives the recording from offset.
+func (s *CallsService) ResumeRecording(ctx context.Context, uuid string, w io.Writer, offset int64) (*Recording, *Response, error) {
+	return s.recording(ctx, uuid, w, offset, "ResumeRecording")
+}
+
+func (s *CallsService) recording(ctx context.Context, uuid string, w io.Writer, offset int64, op string) (*Recording, *Response, error) {
+	url := fmt.Sprintf("%s/%s/recording", s.Endpoint, uuid)
+	req, err := s.client.newRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", mediaTypeAudio)
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	req = withOperation(req, "Calls", op)
+
+	rw := &recordingWriter{w: w, offset: offset, rec: &Recording{Size: -1}}
+	resp, err := s.client.Do(req, rw)
+	rw.rec.Written = rw.written
+
+	return rw.rec, resp, err
+}
+
+// recordingWriter fills in rec from the response and writes the body to w
+// after discarding the first skip bytes.
+type recordingWriter struct {
+	w       io.Writer
+	offset  int64
+	skip    int64
+	written int64
+	rec     *Recording
+}
+
+func (rw *recordingWriter) observeResponse(resp *http.Response) {
+	rw.rec.ContentType = resp.Header.Get("Content-Type")
+	rw.rec.Size = recordingSize(resp)
+	if rw.offset > 0 && resp.StatusCode != http.StatusPartialContent {
+		rw.skip = rw.offset
+	}
+}
+
+func (rw *recordingWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	if rw.skip > 0 {
+		if int64(n) <= rw.skip {
+			rw.skip -= int64(n)
+			return n, nil
+		}
+		p = p[rw.skip:]
+		rw.skip = 0
+	}
+
+	m, err := rw.w.Write(p)
+	rw.written += int64(m)
+	if err != nil {
+		return n - len(p) + m, err
+	}
+
+	return n, nil
+}
+
+// recordingSize returns the total size of the recording in resp, or -1 if it
+// is unknown.
+func recordingSize(resp *http.Response) int64 {
+	if resp.StatusCode == http.StatusPartialContent {
+		// Content-Range: bytes 100-999/1000
+		cr := resp.Header.Get("Content-Range")
+		if i := strings.LastIndexByte(cr, '/'); i >= 0 {
+			if n, err := strconv.ParseInt(cr[i+1:], 10, 64); err == nil {
+				return n
+			}
+		}
+		return -1
+	}
+
+	return resp.ContentLength
+}
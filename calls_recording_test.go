@@ -0,0 +1,178 @@
+package firmafon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testRecording = []byte(strings.Repeat("ID3 recorded audio ", 100))
+
+func testRecordingHandler(t *testing.T, ranges *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", mediaTypeAudio)
+		if ranges != nil {
+			*ranges = append(*ranges, r.Header.Get("Range"))
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(testRecording))
+	}
+}
+
+func TestCallsService_Recording(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/calls/abc/recording", testRecordingHandler(t, nil))
+
+	var buf bytes.Buffer
+	rec, _, err := client.Calls.Recording(context.Background(), "abc", &buf)
+	if err != nil {
+		t.Fatalf("Calls.Recording returned error: %v", err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), testRecording) {
+		t.Errorf("Calls.Recording wrote %d bytes, want the %d byte recording", buf.Len(), len(testRecording))
+	}
+	want := &Recording{ContentType: "audio/mpeg", Size: int64(len(testRecording)), Written: int64(len(testRecording))}
+	if *rec != *want {
+		t.Errorf("Calls.Recording returned %+v, want %+v", rec, want)
+	}
+}
+
+func TestCallsService_ResumeRecording(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var ranges []string
+	mux.HandleFunc("/calls/abc/recording", testRecordingHandler(t, &ranges))
+
+	var buf bytes.Buffer
+	rec, _, err := client.Calls.ResumeRecording(context.Background(), "abc", &buf, 100)
+	if err != nil {
+		t.Fatalf("Calls.ResumeRecording returned error: %v", err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), testRecording[100:]) {
+		t.Errorf("Calls.ResumeRecording wrote %q, want the recording from offset 100", buf.String())
+	}
+	want := &Recording{ContentType: "audio/mpeg", Size: int64(len(testRecording)), Written: int64(len(testRecording) - 100)}
+	if *rec != *want {
+		t.Errorf("Calls.ResumeRecording returned %+v, want %+v", rec, want)
+	}
+	if len(ranges) != 1 || ranges[0] != "bytes=100-" {
+		t.Errorf("Range headers = %q, want [bytes=100-]", ranges)
+	}
+}
+
+func TestCallsService_Recording_operation(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/calls/abc/recording", testRecordingHandler(t, nil))
+
+	var ops []string
+	client.Use(func(next DoFunc) DoFunc {
+		return func(req *http.Request, v interface{}) (*Response, error) {
+			op, _ := OperationFromContext(req.Context())
+			ops = append(ops, op.String())
+			return next(req, v)
+		}
+	})
+
+	if _, _, err := client.Calls.Recording(context.Background(), "abc", io.Discard); err != nil {
+		t.Fatalf("Calls.Recording returned error: %v", err)
+	}
+	if _, _, err := client.Calls.ResumeRecording(context.Background(), "abc", io.Discard, 100); err != nil {
+		t.Fatalf("Calls.ResumeRecording returned error: %v", err)
+	}
+
+	want := []string{"Calls.Recording", "Calls.ResumeRecording"}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("operations = %v, want %v", ops, want)
+	}
+}
+
+func TestCallsService_ResumeRecording_rangeIgnored(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/calls/abc/recording", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Write(testRecording)
+	})
+
+	var buf bytes.Buffer
+	rec, _, err := client.Calls.ResumeRecording(context.Background(), "abc", &buf, 100)
+	if err != nil {
+		t.Fatalf("Calls.ResumeRecording returned error: %v", err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), testRecording[100:]) {
+		t.Errorf("Calls.ResumeRecording did not discard the first 100 bytes: %q", buf.String())
+	}
+	if rec.Written != int64(len(testRecording)-100) {
+		t.Errorf("Written = %d, want %d", rec.Written, len(testRecording)-100)
+	}
+}
+
+func TestCallsService_Recording_notCached(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/calls/abc/recording", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			t.Error("conditional request sent for a recording")
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, "audio")
+	})
+
+	client.HTTPCache = NewMemoryHTTPCache()
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		if _, _, err := client.Calls.Recording(context.Background(), "abc", &buf); err != nil {
+			t.Fatalf("Calls.Recording returned error: %v", err)
+		}
+	}
+}
+
+func TestCallsService_Recording_context(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/calls/abc/recording", testRecordingHandler(t, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var buf bytes.Buffer
+	if _, _, err := client.Calls.Recording(ctx, "abc", &buf); !errors.Is(err, context.Canceled) {
+		t.Errorf("Calls.Recording returned %v, want context.Canceled", err)
+	}
+}
+
+func TestCallsService_Recording_notFound(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/calls/abc/recording", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	var buf bytes.Buffer
+	if _, _, err := client.Calls.Recording(context.Background(), "abc", &buf); err == nil {
+		t.Error("Calls.Recording expected an error but got none")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Calls.Recording wrote the error body %q", buf.String())
+	}
+}
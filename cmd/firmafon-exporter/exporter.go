@@ -0,0 +1,202 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	firmafon "github.com/steffen25/go-firmafon"
+)
+
+const namespace = "firmafon"
+
+// waitBuckets are the histogram buckets for the time callers wait before an
+// inbound call is answered, in seconds.
+var waitBuckets = []float64{5, 10, 15, 20, 30, 45, 60, 90, 120, 180, 300}
+
+var (
+	callsDesc = prometheus.NewDesc(namespace+"_calls",
+		"Calls started within the window by direction and status.",
+		[]string{"direction", "status"}, nil)
+	answerRateDesc = prometheus.NewDesc(namespace+"_answer_rate",
+		"Ratio of inbound calls within the window that were answered.",
+		nil, nil)
+	waitDesc = prometheus.NewDesc(namespace+"_call_wait_seconds",
+		"Time inbound calls within the window waited before being answered.",
+		nil, nil)
+	presenceDesc = prometheus.NewDesc(namespace+"_employees",
+		"Employees by live presence.",
+		[]string{"presence"}, nil)
+	dndDesc = prometheus.NewDesc(namespace+"_employees_do_not_disturb",
+		"Employees with do not disturb enabled.",
+		nil, nil)
+	upDesc = prometheus.NewDesc(namespace+"_up",
+		"Whether the last poll of the Firmafon API succeeded.",
+		nil, nil)
+	lastPollDesc = prometheus.NewDesc(namespace+"_last_poll_timestamp_seconds",
+		"Time of the last successful poll of the Firmafon API.",
+		nil, nil)
+)
+
+// exporter polls the Firmafon API and exposes the calls within a sliding
+// window and the current employee presence as Prometheus metrics.
+type exporter struct {
+	client *firmafon.Client
+	window time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	calls     map[string]*firmafon.Call
+	employees []*firmafon.Employee
+	up        bool
+	lastPoll  time.Time
+}
+
+func newExporter(client *firmafon.Client, window time.Duration) *exporter {
+	return &exporter{
+		client: client,
+		window: window,
+		now:    time.Now,
+		calls:  make(map[string]*firmafon.Call),
+	}
+}
+
+// poll fetches the calls started within the window and all employees.
+func (e *exporter) poll() error {
+	now := e.now()
+	since := now.Add(-e.window)
+
+	calls, _, err := e.client.Calls.GetAll(&firmafon.CallsListOptions{
+		StartedAtGtOrEq: since.UTC().Format(time.RFC3339),
+	})
+	if err == nil {
+		var emps []*firmafon.Employee
+		emps, _, err = e.client.Employees.All()
+		if err == nil {
+			e.update(now, calls, emps)
+			return nil
+		}
+	}
+
+	e.mu.Lock()
+	e.up = false
+	e.mu.Unlock()
+
+	return err
+}
+
+func (e *exporter) update(now time.Time, calls []*firmafon.Call, emps []*firmafon.Employee) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, c := range calls {
+		e.calls[c.CallUUID] = c
+	}
+	since := now.Add(-e.window)
+	for uuid, c := range e.calls {
+		if c.StartedAt.Before(since) {
+			delete(e.calls, uuid)
+		}
+	}
+
+	e.employees = emps
+	e.up = true
+	e.lastPoll = now
+}
+
+func (e *exporter) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{callsDesc, answerRateDesc, waitDesc, presenceDesc, dndDesc, upDesc, lastPollDesc} {
+		ch <- d
+	}
+}
+
+func (e *exporter) Collect(ch chan<- prometheus.Metric) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, boolToFloat(e.up))
+	if e.lastPoll.IsZero() {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(lastPollDesc, prometheus.GaugeValue, float64(e.lastPoll.Unix()))
+
+	since := e.now().Add(-e.window)
+	type key struct{ direction, status string }
+	counts := make(map[key]int)
+	inbound, answered := 0, 0
+	waits := make(map[float64]uint64, len(waitBuckets))
+	for _, b := range waitBuckets {
+		waits[b] = 0
+	}
+	var waitCount uint64
+	var waitSum float64
+
+	for _, c := range e.calls {
+		if c.StartedAt.Before(since) {
+			continue
+		}
+		counts[key{c.Direction, c.Status}]++
+
+		if c.Direction != firmafon.CallDirectionIncoming {
+			continue
+		}
+		inbound++
+		if c.Status != firmafon.CallStatusAnswered || c.AnsweredAt.IsZero() {
+			continue
+		}
+		answered++
+
+		wait := c.AnsweredAt.Sub(c.StartedAt).Seconds()
+		waitCount++
+		waitSum += wait
+		for _, b := range waitBuckets {
+			if wait <= b {
+				waits[b]++
+			}
+		}
+	}
+
+	keys := make([]key, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].direction != keys[j].direction {
+			return keys[i].direction < keys[j].direction
+		}
+		return keys[i].status < keys[j].status
+	})
+	for _, k := range keys {
+		ch <- prometheus.MustNewConstMetric(callsDesc, prometheus.GaugeValue, float64(counts[k]), k.direction, k.status)
+	}
+
+	if inbound > 0 {
+		ch <- prometheus.MustNewConstMetric(answerRateDesc, prometheus.GaugeValue, float64(answered)/float64(inbound))
+	}
+	ch <- prometheus.MustNewConstHistogram(waitDesc, waitCount, waitSum, waits)
+
+	presence := make(map[string]int)
+	dnd := 0
+	for _, emp := range e.employees {
+		p := emp.LivePresence
+		if p == "" {
+			p = "unknown"
+		}
+		presence[p]++
+		if emp.DoNotDisturb {
+			dnd++
+		}
+	}
+	for p, n := range presence {
+		ch <- prometheus.MustNewConstMetric(presenceDesc, prometheus.GaugeValue, float64(n), p)
+	}
+	ch <- prometheus.MustNewConstMetric(dndDesc, prometheus.GaugeValue, float64(dnd))
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
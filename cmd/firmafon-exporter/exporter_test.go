@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	firmafon "github.com/steffen25/go-firmafon"
+)
+
+func TestExporter_Metrics(t *testing.T) {
+	now := time.Date(2014, 3, 21, 14, 0, 0, 0, time.UTC)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v2/calls", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Query().Get("started_at_gt_or_eq"), "2014-03-21T13:00:00Z"; got != want {
+			t.Errorf("started_at_gt_or_eq = %q, want %q", got, want)
+		}
+		fmt.Fprint(w, `{"calls": [
+			{"call_uuid": "1", "direction": "incoming", "status": "answered",
+			 "started_at": "2014-03-21T13:10:00Z", "answered_at": "2014-03-21T13:10:08Z", "ended_at": "2014-03-21T13:12:00Z"},
+			{"call_uuid": "2", "direction": "incoming", "status": "answered",
+			 "started_at": "2014-03-21T13:20:00Z", "answered_at": "2014-03-21T13:20:40Z", "ended_at": "2014-03-21T13:22:00Z"},
+			{"call_uuid": "3", "direction": "incoming", "status": "missed",
+			 "started_at": "2014-03-21T13:30:00Z", "ended_at": "2014-03-21T13:31:00Z"},
+			{"call_uuid": "4", "direction": "outgoing", "status": "answered",
+			 "started_at": "2014-03-21T13:40:00Z", "answered_at": "2014-03-21T13:40:05Z", "ended_at": "2014-03-21T13:45:00Z"}
+		]}`)
+	})
+	mux.HandleFunc("/api/v2/employees", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"employees": [
+			{"id": 1, "live_presence": "available"},
+			{"id": 2, "live_presence": "available", "do_not_disturb": true},
+			{"id": 3, "live_presence": "busy"}
+		]}`)
+	})
+	api := httptest.NewServer(mux)
+	defer api.Close()
+
+	client := firmafon.NewClient("token")
+	client.BaseURL, _ = url.Parse(api.URL + "/api/v2/")
+
+	e := newExporter(client, time.Hour)
+	e.now = func() time.Time { return now }
+	if err := e.poll(); err != nil {
+		t.Fatalf("poll returned error: %v", err)
+	}
+
+	body := scrape(t, e)
+	for _, want := range []string{
+		`firmafon_up 1`,
+		`firmafon_calls{direction="incoming",status="answered"} 2`,
+		`firmafon_calls{direction="incoming",status="missed"} 1`,
+		`firmafon_calls{direction="outgoing",status="answered"} 1`,
+		`firmafon_answer_rate 0.6666666666666666`,
+		`firmafon_call_wait_seconds_bucket{le="5"} 0`,
+		`firmafon_call_wait_seconds_bucket{le="10"} 1`,
+		`firmafon_call_wait_seconds_bucket{le="45"} 2`,
+		`firmafon_call_wait_seconds_sum 48`,
+		`firmafon_call_wait_seconds_count 2`,
+		`firmafon_employees{presence="available"} 2`,
+		`firmafon_employees{presence="busy"} 1`,
+		`firmafon_employees_do_not_disturb 1`,
+	} {
+		if !strings.Contains(body, want+"\n") {
+			t.Errorf("metrics do not contain %q", want)
+		}
+	}
+
+	// calls sliding out of the window are dropped
+	now = now.Add(25 * time.Minute)
+	body = scrape(t, e)
+	if !strings.Contains(body, `firmafon_calls{direction="incoming",status="missed"} 1`) {
+		t.Error("missed call within the window was dropped")
+	}
+	if strings.Contains(body, `status="answered"} 2`) {
+		t.Error("answered calls outside the window are still counted")
+	}
+}
+
+func TestExporter_PollError(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}))
+	defer api.Close()
+
+	client := firmafon.NewClient("token")
+	client.BaseURL, _ = url.Parse(api.URL + "/api/v2/")
+
+	e := newExporter(client, time.Hour)
+	if err := e.poll(); err == nil {
+		t.Fatal("poll expected an error but got none")
+	}
+
+	body := scrape(t, e)
+	if !strings.Contains(body, "firmafon_up 0\n") {
+		t.Error("firmafon_up is not 0 after a failed poll")
+	}
+	if strings.Contains(body, "firmafon_calls") {
+		t.Error("call metrics are exposed before the first successful poll")
+	}
+}
+
+func scrape(t *testing.T, e *exporter) string {
+	t.Helper()
+
+	server := httptest.NewServer(newHandler(e))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("scrape failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading metrics failed: %v", err)
+	}
+
+	return string(body)
+}
@@ -0,0 +1,71 @@
+// Command firmafon-exporter exposes Firmafon call and presence metrics for
+// Prometheus.
+//
+// It polls the calls started within a sliding window and all employees at a
+// fixed interval and serves the metrics on /metrics:
+//
+//	FIRMAFON_TOKEN=token firmafon-exporter -listen :9550 -interval 1m -window 1h
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	firmafon "github.com/steffen25/go-firmafon"
+)
+
+func main() {
+	listen := flag.String("listen", ":9550", "address to serve metrics on")
+	interval := flag.Duration("interval", time.Minute, "how often to poll the Firmafon API")
+	window := flag.Duration("window", time.Hour, "sliding window for call metrics")
+	baseURL := flag.String("base-url", "", "Firmafon API base URL (defaults to the public API)")
+	flag.Parse()
+
+	token := os.Getenv("FIRMAFON_TOKEN")
+	if token == "" {
+		log.Fatal("FIRMAFON_TOKEN must be set")
+	}
+
+	client := firmafon.NewClient(token)
+	if *baseURL != "" {
+		u, err := url.Parse(*baseURL)
+		if err != nil {
+			log.Fatalf("invalid -base-url: %v", err)
+		}
+		client.BaseURL = u
+	}
+
+	e := newExporter(client, *window)
+	go func() {
+		for {
+			if err := e.poll(); err != nil {
+				log.Printf("polling Firmafon failed: %v", err)
+			}
+			time.Sleep(*interval)
+		}
+	}()
+
+	log.Printf("serving metrics on %s/metrics", *listen)
+	log.Fatal(http.ListenAndServe(*listen, newHandler(e)))
+}
+
+func newHandler(e *exporter) http.Handler {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(e, collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `<html><body><a href="/metrics">Metrics</a></body></html>`)
+	})
+
+	return mux
+}
@@ -0,0 +1,147 @@
+package firmafon
+
+import "time"
+
+// Subscription statuses returned by the API.
+const (
+	SubscriptionStatusTrial     = "trial"
+	SubscriptionStatusActive    = "active"
+	SubscriptionStatusCancelled = "cancelled"
+)
+
+type CompanyService service
+
+// Company is the company the authenticated employee belongs to.
+type Company struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	ZipCode   string    `json:"zip_code"`
+	City      string    `json:"city"`
+	Country   string    `json:"country"`
+	VATNumber string    `json:"vat_number"`
+	Email     string    `json:"email"`
+	Number    string    `json:"number"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CompanySettings are the company wide settings.
+type CompanySettings struct {
+	Language            string `json:"language"`
+	TimeZone            string `json:"time_zone"`
+	ShowNumberOnOutcall bool   `json:"show_number_on_outcall"`
+	RecordCalls         bool   `json:"record_calls"`
+	VoicemailEnabled    bool   `json:"voicemail_enabled"`
+}
+
+// Subscription is the subscription of the company.
+type Subscription struct {
+	Plan        string     `json:"plan"`
+	Status      string     `json:"status"`
+	Seats       int        `json:"seats"`
+	TrialEndsAt *time.Time `json:"trial_ends_at"`
+	RenewsAt    *time.Time `json:"renews_at"`
+}
+
+// InTrial reports whether the company is in trial. Some features, such as
+// EmployeesService.SendSMS, are not available in trial.
+func (s *Subscription) InTrial() bool {
+	return s.Status == SubscriptionStatusTrial
+}
+
+// CompanyNumber is a phone number owned by the company.
+type CompanyNumber struct {
+	ID      int    `json:"id"`
+	Number  string `json:"number"`
+	Name    string `json:"name"`
+	Primary bool   `json:"primary"`
+
+	// Endpoint is where incoming calls to the number are routed, e.g. a
+	// reception or an employee.
+	Endpoint Endpoint `json:"endpoint"`
+}
+
+type firmafonCompany struct {
+	Company *Company `json:"company"`
+}
+
+type firmafonCompanySettings struct {
+	Settings *CompanySettings `json:"settings"`
+}
+
+type firmafonSubscription struct {
+	Subscription *Subscription `json:"subscription"`
+}
+
+type firmafonCompanyNumbers struct {
+	Numbers []*CompanyNumber `json:"numbers"`
+}
+
+// Get returns the company of the authenticated employee.
+func (s *CompanyService) Get() (*Company, *Response, error) {
+	req, err := s.client.NewRequest("GET", "company", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "Company", "Get")
+
+	c := new(firmafonCompany)
+	resp, err := s.client.Do(req, &c)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return c.Company, resp, nil
+}
+
+// Settings returns the company wide settings.
+func (s *CompanyService) Settings() (*CompanySettings, *Response, error) {
+	req, err := s.client.NewRequest("GET", "company/settings", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "Company", "Settings")
+
+	settings := new(firmafonCompanySettings)
+	resp, err := s.client.Do(req, &settings)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return settings.Settings, resp, nil
+}
+
+// Subscription returns the subscription of the company, including whether it
+// is in trial.
+func (s *CompanyService) Subscription() (*Subscription, *Response, error) {
+	req, err := s.client.NewRequest("GET", "company/subscription", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "Company", "Subscription")
+
+	sub := new(firmafonSubscription)
+	resp, err := s.client.Do(req, &sub)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sub.Subscription, resp, nil
+}
+
+// Numbers returns the phone numbers owned by the company.
+func (s *CompanyService) Numbers() ([]*CompanyNumber, *Response, error) {
+	req, err := s.client.NewRequest("GET", "company/numbers", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "Company", "Numbers")
+
+	numbers := new(firmafonCompanyNumbers)
+	resp, err := s.client.Do(req, &numbers)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return numbers.Numbers, resp, nil
+}
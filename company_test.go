@@ -0,0 +1,142 @@
+package firmafon
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCompanyService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/company", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", mediaTypeJSON)
+		fmt.Fprint(w, `{
+		  "company": {
+			"id": 1,
+			"name": "Firma ApS",
+			"address": "Vestergade 1",
+			"zip_code": "8000",
+			"city": "Aarhus C",
+			"country": "DK",
+			"vat_number": "12345678",
+			"email": "info@example.com",
+			"number": "4571999999",
+			"created_at": "2014-03-21T13:59:04Z"
+		  }
+		}`)
+	})
+
+	company, _, err := client.Company.Get()
+	if err != nil {
+		t.Errorf("Company.Get returned error: %v", err)
+	}
+
+	want := &Company{
+		ID:        1,
+		Name:      "Firma ApS",
+		Address:   "Vestergade 1",
+		ZipCode:   "8000",
+		City:      "Aarhus C",
+		Country:   "DK",
+		VATNumber: "12345678",
+		Email:     "info@example.com",
+		Number:    "4571999999",
+		CreatedAt: time.Date(2014, 3, 21, 13, 59, 4, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(company, want) {
+		t.Errorf("Company.Get returned %+v, want %+v", company, want)
+	}
+}
+
+func TestCompanyService_Settings(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/company/settings", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"settings": {"language": "da", "time_zone": "Europe/Copenhagen", "show_number_on_outcall": true, "record_calls": false, "voicemail_enabled": true}}`)
+	})
+
+	settings, _, err := client.Company.Settings()
+	if err != nil {
+		t.Errorf("Company.Settings returned error: %v", err)
+	}
+
+	want := &CompanySettings{
+		Language:            "da",
+		TimeZone:            "Europe/Copenhagen",
+		ShowNumberOnOutcall: true,
+		VoicemailEnabled:    true,
+	}
+	if !reflect.DeepEqual(settings, want) {
+		t.Errorf("Company.Settings returned %+v, want %+v", settings, want)
+	}
+}
+
+func TestCompanyService_Subscription(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/company/subscription", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"subscription": {"plan": "basis", "status": "trial", "seats": 3, "trial_ends_at": "2014-04-21T00:00:00Z", "renews_at": null}}`)
+	})
+
+	sub, _, err := client.Company.Subscription()
+	if err != nil {
+		t.Errorf("Company.Subscription returned error: %v", err)
+	}
+
+	trialEnds := time.Date(2014, 4, 21, 0, 0, 0, 0, time.UTC)
+	want := &Subscription{Plan: "basis", Status: SubscriptionStatusTrial, Seats: 3, TrialEndsAt: &trialEnds}
+	if !reflect.DeepEqual(sub, want) {
+		t.Errorf("Company.Subscription returned %+v, want %+v", sub, want)
+	}
+	if !sub.InTrial() {
+		t.Errorf("InTrial returned false, want true")
+	}
+	if (&Subscription{Status: SubscriptionStatusActive}).InTrial() {
+		t.Errorf("InTrial returned true for an active subscription, want false")
+	}
+}
+
+func TestCompanyService_Numbers(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/company/numbers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"numbers": [{"id": 1, "number": "4571999999", "name": "Hovednummer", "primary": true}, {"id": 2, "number": "4571999998", "name": "Support", "primary": false}]}`)
+	})
+
+	numbers, _, err := client.Company.Numbers()
+	if err != nil {
+		t.Errorf("Company.Numbers returned error: %v", err)
+	}
+
+	want := []*CompanyNumber{
+		{ID: 1, Number: "4571999999", Name: "Hovednummer", Primary: true},
+		{ID: 2, Number: "4571999998", Name: "Support"},
+	}
+	if !reflect.DeepEqual(numbers, want) {
+		t.Errorf("Company.Numbers returned %+v, want %+v", numbers, want)
+	}
+}
+
+func TestCompanyService_Get_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/company", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	})
+
+	if _, _, err := client.Company.Get(); err == nil {
+		t.Error("Company.Get expected an error but got none")
+	}
+}
@@ -0,0 +1,146 @@
+package firmafon
+
+import "fmt"
+
+type ContactsService service
+
+// Contact is an entry in the company phonebook. Calls from and to a contact's
+// number show the contact in Call.FromContact and Call.ToContact.
+type Contact struct {
+	ID     int    `json:"id,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Number string `json:"number,omitempty"`
+	Email  string `json:"email,omitempty"`
+}
+
+// ContactsListOptions filters the contacts returned by ContactsService.List.
+type ContactsListOptions struct {
+	// Number only returns contacts with the given number.
+	Number PhoneNumber `url:"number,omitempty"`
+
+	// Query only returns contacts whose name, number or email contains it.
+	Query string `url:"q,omitempty"`
+}
+
+type firmafonContacts struct {
+	Contacts []*Contact `json:"contacts"`
+}
+
+type firmafonContact struct {
+	Contact *Contact `json:"contact"`
+}
+
+// List returns the contacts in the phonebook. opt may be nil.
+func (s *ContactsService) List(opt *ContactsListOptions) ([]*Contact, *Response, error) {
+	url, err := addOptions("contacts", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "Contacts", "List")
+
+	contacts := new(firmafonContacts)
+	resp, err := s.client.Do(req, &contacts)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return contacts.Contacts, resp, nil
+}
+
+// SearchByNumber returns the contacts with the given number, which may be in
+// any format accepted by ParsePhoneNumber.
+func (s *ContactsService) SearchByNumber(number string) ([]*Contact, *Response, error) {
+	n, err := ParsePhoneNumber(number)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.List(&ContactsListOptions{Number: n})
+}
+
+// Get returns a contact by ID.
+func (s *ContactsService) Get(id int) (*Contact, *Response, error) {
+	url := fmt.Sprintf("contacts/%d", id)
+	req, err := s.client.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "Contacts", "Get")
+
+	contact := new(firmafonContact)
+	resp, err := s.client.Do(req, &contact)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return contact.Contact, resp, nil
+}
+
+// Create adds a contact to the phonebook.
+func (s *ContactsService) Create(c *Contact) (*Contact, *Response, error) {
+	req, err := s.client.NewRequest("POST", "contacts", firmafonContact{c})
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "Contacts", "Create")
+
+	contact := new(firmafonContact)
+	resp, err := s.client.Do(req, &contact)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return contact.Contact, resp, nil
+}
+
+// Update updates a contact by ID.
+func (s *ContactsService) Update(c *Contact) (*Contact, *Response, error) {
+	url := fmt.Sprintf("contacts/%d", c.ID)
+	req, err := s.client.NewRequest("PUT", url, firmafonContact{c})
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "Contacts", "Update")
+
+	contact := new(firmafonContact)
+	resp, err := s.client.Do(req, &contact)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return contact.Contact, resp, nil
+}
+
+// Delete removes a contact from the phonebook.
+func (s *ContactsService) Delete(id int) (*Response, error) {
+	url := fmt.Sprintf("contacts/%d", id)
+	req, err := s.client.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = withOperation(req, "Contacts", "Delete")
+
+	return s.client.Do(req, nil)
+}
+
+// Import adds many contacts in a single request and returns the created
+// contacts.
+func (s *ContactsService) Import(contacts []*Contact) ([]*Contact, *Response, error) {
+	req, err := s.client.NewRequest("POST", "contacts/import", firmafonContacts{contacts})
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "Contacts", "Import")
+
+	created := new(firmafonContacts)
+	resp, err := s.client.Do(req, &created)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return created.Contacts, resp, nil
+}
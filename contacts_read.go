@@ -0,0 +1,320 @@
+package firmafon
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrNoNumberColumn is returned by ReadContactsCSV when the header has no
+// column holding phone numbers.
+var ErrNoNumberColumn = errors.New("firmafon: contacts CSV has no number column")
+
+// Header names recognized by ReadContactsCSV, compared case-insensitively.
+var (
+	contactNameColumns      = []string{"name", "full name", "navn"}
+	contactFirstNameColumns = []string{"first name", "first_name", "fornavn"}
+	contactLastNameColumns  = []string{"last name", "last_name", "efternavn"}
+	contactNumberColumns    = []string{"number", "phone", "telephone", "tel", "mobile", "nummer", "telefon", "mobil"}
+	contactEmailColumns     = []string{"email", "e-mail", "mail"}
+)
+
+// ContactCSVOptions configures ReadContactsCSV.
+type ContactCSVOptions struct {
+	// Comma is the field delimiter. If zero, it is detected from the header
+	// as either ';' or ','.
+	Comma rune
+}
+
+// ReadContactsCSV reads contacts from CSV with a header row. The columns are
+// found by name, e.g. "name", "number" or "phone", and "email", in English or
+// Danish; separate first and last name columns are joined. Numbers are
+// returned as found, see ContactSync for normalization. opt may be nil.
+func ReadContactsCSV(r io.Reader, opt *ContactCSVOptions) ([]*Contact, error) {
+	br := bufio.NewReader(r)
+	if bom, err := br.Peek(len(utf8BOM)); err == nil && string(bom) == utf8BOM {
+		br.Discard(len(utf8BOM))
+	}
+
+	comma := rune(0)
+	if opt != nil {
+		comma = opt.Comma
+	}
+	if comma == 0 {
+		comma = ','
+		header, _ := br.Peek(br.Buffered())
+		if len(header) == 0 {
+			header, _ = br.Peek(4096)
+		}
+		line := string(header)
+		if i := strings.IndexAny(line, "\r\n"); i >= 0 {
+			line = line[:i]
+		}
+		if strings.Count(line, ";") > strings.Count(line, ",") {
+			comma = ';'
+		}
+	}
+
+	cr := csv.NewReader(br)
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	name := findColumn(header, contactNameColumns)
+	first := findColumn(header, contactFirstNameColumns)
+	last := findColumn(header, contactLastNameColumns)
+	number := findColumn(header, contactNumberColumns)
+	email := findColumn(header, contactEmailColumns)
+	if number < 0 {
+		return nil, ErrNoNumberColumn
+	}
+
+	var contacts []*Contact
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		c := &Contact{
+			Name:   field(record, name),
+			Number: field(record, number),
+			Email:  field(record, email),
+		}
+		if c.Name == "" {
+			c.Name = strings.TrimSpace(field(record, first) + " " + field(record, last))
+		}
+		if c.Name == "" && c.Number == "" && c.Email == "" {
+			continue
+		}
+		contacts = append(contacts, c)
+	}
+
+	return contacts, nil
+}
+
+func findColumn(header []string, names []string) int {
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+		for _, name := range names {
+			if h == name {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func field(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[i])
+}
+
+// ReadVCards reads contacts from vCard 3.0 or 4.0 data holding one or more
+// cards. A Firmafon contact has a single number, so the preferred telephone
+// number of each card is used, or the first one if none is preferred. Cards
+// without a telephone number are returned with an empty number.
+func ReadVCards(r io.Reader) ([]*Contact, error) {
+	lines, err := unfoldVCardLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		contacts []*Contact
+		card     *vCard
+	)
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		prop, params, value, ok := parseVCardLine(line)
+		if !ok {
+			return nil, fmt.Errorf("firmafon: invalid vCard line %d: %q", i+1, line)
+		}
+
+		switch {
+		case prop == "BEGIN" && strings.EqualFold(value, "VCARD"):
+			card = &vCard{}
+		case card == nil:
+			return nil, fmt.Errorf("firmafon: vCard line %d is outside BEGIN:VCARD", i+1)
+		case prop == "END" && strings.EqualFold(value, "VCARD"):
+			contacts = append(contacts, card.contact())
+			card = nil
+		default:
+			card.set(prop, params, value)
+		}
+	}
+	if card != nil {
+		return nil, errors.New("firmafon: vCard is missing END:VCARD")
+	}
+
+	return contacts, nil
+}
+
+type vCard struct {
+	fn, n     string
+	tel       string
+	telPref   bool
+	email     string
+	emailPref bool
+}
+
+func (c *vCard) set(prop string, params map[string]string, value string) {
+	switch prop {
+	case "FN":
+		c.fn = unescapeVCard(value)
+	case "N":
+		// family;given;additional;prefix;suffix
+		parts := splitVCard(value)
+		name := []string{}
+		for _, i := range []int{3, 1, 2, 0, 4} {
+			if i < len(parts) && parts[i] != "" {
+				name = append(name, parts[i])
+			}
+		}
+		c.n = strings.Join(name, " ")
+	case "TEL":
+		value = unescapeVCard(value)
+		value = strings.TrimPrefix(value, "tel:")
+		if i := strings.IndexByte(value, ';'); i >= 0 {
+			// strip URI parameters such as ;ext=
+			value = value[:i]
+		}
+		pref := isPreferred(params)
+		if c.tel == "" || (pref && !c.telPref) {
+			c.tel, c.telPref = value, pref
+		}
+	case "EMAIL":
+		pref := isPreferred(params)
+		if c.email == "" || (pref && !c.emailPref) {
+			c.email, c.emailPref = unescapeVCard(value), pref
+		}
+	}
+}
+
+func (c *vCard) contact() *Contact {
+	name := c.fn
+	if name == "" {
+		name = c.n
+	}
+
+	return &Contact{Name: name, Number: c.tel, Email: c.email}
+}
+
+// isPreferred reports whether the parameters mark a value as preferred, with
+// TYPE=pref in vCard 3.0 or PREF=1 in vCard 4.0.
+func isPreferred(params map[string]string) bool {
+	if p, ok := params["PREF"]; ok && p != "" {
+		return true
+	}
+	for _, t := range strings.Split(params["TYPE"], ",") {
+		if strings.EqualFold(t, "pref") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unfoldVCardLines splits r into lines, joining folded lines that start with
+// a space or a tab with the previous line.
+func unfoldVCardLines(r io.Reader) ([]string, error) {
+	var lines []string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimRight(s.Text(), "\r")
+		if len(lines) == 0 {
+			line = strings.TrimPrefix(line, utf8BOM)
+		}
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines, s.Err()
+}
+
+// parseVCardLine splits a content line such as
+// "item1.TEL;TYPE=cell,pref:+45 12 34 56 78" into its upper case property
+// name without group, its parameters and its value.
+func parseVCardLine(line string) (prop string, params map[string]string, value string, ok bool) {
+	i := strings.IndexByte(line, ':')
+	if i <= 0 {
+		return "", nil, "", false
+	}
+	value = line[i+1:]
+
+	parts := strings.Split(line[:i], ";")
+	prop = strings.ToUpper(parts[0])
+	if j := strings.LastIndexByte(prop, '.'); j >= 0 {
+		prop = prop[j+1:]
+	}
+
+	params = make(map[string]string)
+	for _, p := range parts[1:] {
+		k, v, found := strings.Cut(p, "=")
+		if !found {
+			// vCard 2.1 style bare types, e.g. TEL;CELL;PREF
+			k, v = "TYPE", p
+		}
+		k = strings.ToUpper(k)
+		v = strings.Trim(v, `"`)
+		if params[k] != "" {
+			v = params[k] + "," + v
+		}
+		params[k] = v
+	}
+
+	return prop, params, value, true
+}
+
+// splitVCard splits a structured value on unescaped semicolons.
+func splitVCard(value string) []string {
+	var parts []string
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i+1 < len(value):
+			b.WriteByte(value[i])
+			b.WriteByte(value[i+1])
+			i++
+		case value[i] == ';':
+			parts = append(parts, unescapeVCard(b.String()))
+			b.Reset()
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+
+	return append(parts, unescapeVCard(b.String()))
+}
+
+func unescapeVCard(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
@@ -0,0 +1,117 @@
+package firmafon
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadContactsCSV(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		opt  *ContactCSVOptions
+		want []*Contact
+	}{
+		{
+			name: "comma",
+			in:   "Name,Phone,Email\nJohn Doe,+45 12 34 56 78,john@example.com\n\"Doe, Jane\",87654321,\n",
+			want: []*Contact{
+				{Name: "John Doe", Number: "+45 12 34 56 78", Email: "john@example.com"},
+				{Name: "Doe, Jane", Number: "87654321"},
+			},
+		},
+		{
+			name: "semicolon with BOM and Danish headers",
+			in:   utf8BOM + "Fornavn;Efternavn;Telefon;E-mail\r\nJens;Hansen;12345678;jens@example.com\r\n;;;\r\n",
+			want: []*Contact{
+				{Name: "Jens Hansen", Number: "12345678", Email: "jens@example.com"},
+			},
+		},
+		{
+			name: "explicit delimiter",
+			in:   "number\tname\n12345678\tJohn, Doe\n",
+			opt:  &ContactCSVOptions{Comma: '\t'},
+			want: []*Contact{
+				{Name: "John, Doe", Number: "12345678"},
+			},
+		},
+		{
+			name: "empty",
+			in:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contacts, err := ReadContactsCSV(strings.NewReader(tt.in), tt.opt)
+			if err != nil {
+				t.Fatalf("ReadContactsCSV returned error: %v", err)
+			}
+			if !reflect.DeepEqual(contacts, tt.want) {
+				t.Errorf("ReadContactsCSV returned %+v, want %+v", contacts, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadContactsCSV_noNumberColumn(t *testing.T) {
+	_, err := ReadContactsCSV(strings.NewReader("name,email\nJohn,john@example.com\n"), nil)
+	if !errors.Is(err, ErrNoNumberColumn) {
+		t.Errorf("ReadContactsCSV returned error %v, want %v", err, ErrNoNumberColumn)
+	}
+}
+
+func TestReadVCards(t *testing.T) {
+	in := "BEGIN:VCARD\r\n" +
+		"VERSION:3.0\r\n" +
+		"N:Doe;John;;Dr.;\r\n" +
+		"FN:John Doe\\, Jr.\r\n" +
+		"TEL;TYPE=work:+45 11 11 11 11\r\n" +
+		"item1.TEL;TYPE=cell,pref:+45 12 34 \r\n" +
+		" 56 78\r\n" +
+		"EMAIL;TYPE=internet:john@example.com\r\n" +
+		"END:VCARD\r\n" +
+		"\r\n" +
+		"BEGIN:VCARD\r\n" +
+		"VERSION:4.0\r\n" +
+		"N:Hansen;Jens;;;\r\n" +
+		"TEL;VALUE=uri;TYPE=home:tel:+45-87-65-43-21\r\n" +
+		"TEL;VALUE=uri;PREF=1;TYPE=cell:tel:+45-22-22-22-22;ext=12\r\n" +
+		"EMAIL:jens@example.com\r\n" +
+		"EMAIL;PREF=1:hansen@example.com\r\n" +
+		"END:VCARD\r\n" +
+		"BEGIN:VCARD\n" +
+		"VERSION:3.0\n" +
+		"FN:No Number\n" +
+		"END:VCARD\n"
+
+	contacts, err := ReadVCards(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("ReadVCards returned error: %v", err)
+	}
+
+	want := []*Contact{
+		{Name: "John Doe, Jr.", Number: "+45 12 34 56 78", Email: "john@example.com"},
+		{Name: "Jens Hansen", Number: "+45-22-22-22-22", Email: "hansen@example.com"},
+		{Name: "No Number"},
+	}
+	if !reflect.DeepEqual(contacts, want) {
+		t.Errorf("ReadVCards returned %+v, want %+v", contacts, want)
+	}
+}
+
+func TestReadVCards_invalid(t *testing.T) {
+	tests := map[string]string{
+		"no colon":    "BEGIN:VCARD\nFN John\nEND:VCARD\n",
+		"outside":     "FN:John\n",
+		"missing END": "BEGIN:VCARD\nFN:John\n",
+	}
+
+	for name, in := range tests {
+		if _, err := ReadVCards(strings.NewReader(in)); err == nil {
+			t.Errorf("ReadVCards(%s) expected an error but got none", name)
+		}
+	}
+}
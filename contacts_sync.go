@@ -0,0 +1,258 @@
+package firmafon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Actions in a ContactPlan.
+const (
+	ContactCreate ContactAction = "create"
+	ContactUpdate ContactAction = "update"
+	ContactDelete ContactAction = "delete"
+)
+
+// ContactAction is what a ContactChange does to the phonebook.
+type ContactAction string
+
+// ContactChange is a single change in a ContactPlan.
+type ContactChange struct {
+	Action ContactAction
+
+	// Contact is the contact to create, the updated contact or the contact
+	// to delete.
+	Contact *Contact
+
+	// Previous is the existing contact for an update, and nil otherwise.
+	Previous *Contact
+}
+
+// SkippedContact is a source contact left out of a ContactPlan.
+type SkippedContact struct {
+	Contact *Contact
+	Reason  string
+}
+
+// ContactPlan is the set of changes that brings the phonebook in line with a
+// list of source contacts.
+type ContactPlan struct {
+	Changes []*ContactChange
+	Skipped []*SkippedContact
+}
+
+// Count returns the number of changes with the given action.
+func (p *ContactPlan) Count(action ContactAction) int {
+	n := 0
+	for _, c := range p.Changes {
+		if c.Action == action {
+			n++
+		}
+	}
+
+	return n
+}
+
+// WriteTo writes the plan to w in a human readable form, one line per change
+// or skipped contact followed by a summary, e.g. for a dry run.
+func (p *ContactPlan) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	for _, c := range p.Changes {
+		switch c.Action {
+		case ContactUpdate:
+			fmt.Fprintf(cw, "update %s: %s <%s> -> %s <%s>\n", c.Contact.Number,
+				c.Previous.Name, c.Previous.Email, c.Contact.Name, c.Contact.Email)
+		default:
+			fmt.Fprintf(cw, "%s %s: %s <%s>\n", c.Action, c.Contact.Number, c.Contact.Name, c.Contact.Email)
+		}
+	}
+	for _, s := range p.Skipped {
+		fmt.Fprintf(cw, "skip %q: %s\n", s.Contact.Number, s.Reason)
+	}
+	fmt.Fprintf(cw, "%d to create, %d to update, %d to delete, %d skipped\n",
+		p.Count(ContactCreate), p.Count(ContactUpdate), p.Count(ContactDelete), len(p.Skipped))
+
+	return cw.n, cw.err
+}
+
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(b []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(b)
+	cw.n += int64(n)
+	cw.err = err
+
+	return n, err
+}
+
+// ContactSyncOptions configures a ContactSync.
+type ContactSyncOptions struct {
+	// Delete removes existing contacts whose number is not in the source.
+	// By default they are kept.
+	Delete bool
+
+	// Concurrency is the number of changes applied at the same time. If
+	// zero, 4 is used.
+	Concurrency int
+}
+
+// A ContactSync keeps the company phonebook in sync with contacts from
+// another source, e.g. read with ReadContactsCSV or ReadVCards. Contacts are
+// matched by number after normalizing it with ParsePhoneNumber, so
+// "+45 12 34 56 78" and "12345678" are the same contact.
+type ContactSync struct {
+	contacts ContactsAPI
+	opt      ContactSyncOptions
+}
+
+// NewContactSync returns a ContactSync changing the phonebook through s,
+// usually Client.Contacts. opt may be nil.
+func NewContactSync(s ContactsAPI, opt *ContactSyncOptions) *ContactSync {
+	cs := &ContactSync{contacts: s}
+	if opt != nil {
+		cs.opt = *opt
+	}
+	if cs.opt.Concurrency <= 0 {
+		cs.opt.Concurrency = 4
+	}
+
+	return cs
+}
+
+// Plan compares source with the existing contacts and returns the changes
+// needed, without making them. A source contact with a new number is
+// created. A matching contact is updated if the source has a different,
+// non-empty name or email; empty source fields never clear existing ones.
+// Source contacts with an invalid or repeated number are skipped.
+func (cs *ContactSync) Plan(source []*Contact) (*ContactPlan, error) {
+	existing, _, err := cs.contacts.List(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	byNumber := make(map[string]*Contact, len(existing))
+	for _, c := range existing {
+		if n, err := ParsePhoneNumber(c.Number); err == nil {
+			byNumber[n.Digits()] = c
+		}
+	}
+
+	plan := &ContactPlan{}
+	seen := make(map[string]bool, len(source))
+	for _, src := range source {
+		n, err := ParsePhoneNumber(src.Number)
+		if err != nil {
+			plan.Skipped = append(plan.Skipped, &SkippedContact{Contact: src, Reason: err.Error()})
+			continue
+		}
+		key := n.Digits()
+		if seen[key] {
+			plan.Skipped = append(plan.Skipped, &SkippedContact{Contact: src, Reason: "duplicate number"})
+			continue
+		}
+		seen[key] = true
+
+		prev, ok := byNumber[key]
+		if !ok {
+			plan.Changes = append(plan.Changes, &ContactChange{
+				Action:  ContactCreate,
+				Contact: &Contact{Name: src.Name, Number: key, Email: src.Email},
+			})
+			continue
+		}
+
+		updated := *prev
+		if src.Name != "" {
+			updated.Name = src.Name
+		}
+		if src.Email != "" {
+			updated.Email = src.Email
+		}
+		if updated != *prev {
+			plan.Changes = append(plan.Changes, &ContactChange{Action: ContactUpdate, Contact: &updated, Previous: prev})
+		}
+	}
+
+	if cs.opt.Delete {
+		var deletes []*ContactChange
+		for key, c := range byNumber {
+			if !seen[key] {
+				deletes = append(deletes, &ContactChange{Action: ContactDelete, Contact: c})
+			}
+		}
+		sort.Slice(deletes, func(i, j int) bool { return deletes[i].Contact.ID < deletes[j].Contact.ID })
+		plan.Changes = append(plan.Changes, deletes...)
+	}
+
+	return plan, nil
+}
+
+// Apply makes the changes in plan, at most ContactSyncOptions.Concurrency at
+// a time. It returns once all started changes are done. If ctx is done, no
+// more changes are started and ctx.Err() is returned along with the errors
+// of the failed changes.
+func (cs *ContactSync) Apply(ctx context.Context, plan *ContactPlan) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		sem  = make(chan struct{}, cs.opt.Concurrency)
+	)
+
+	for _, change := range plan.Changes {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(change *ContactChange) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := cs.apply(change); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s %s: %w", change.Action, change.Contact.Number, err))
+				mu.Unlock()
+			}
+		}(change)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		errs = append([]error{err}, errs...)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (cs *ContactSync) apply(change *ContactChange) error {
+	var err error
+	switch change.Action {
+	case ContactCreate:
+		_, _, err = cs.contacts.Create(change.Contact)
+	case ContactUpdate:
+		_, _, err = cs.contacts.Update(change.Contact)
+	case ContactDelete:
+		_, err = cs.contacts.Delete(change.Contact.ID)
+	default:
+		err = fmt.Errorf("firmafon: unknown contact action %q", change.Action)
+	}
+
+	return err
+}
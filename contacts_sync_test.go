@@ -0,0 +1,239 @@
+package firmafon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestContactSync_Plan(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/contacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"contacts": [
+		  {"id": 1, "name": "John Doe", "number": "4512345678", "email": "john@example.com"},
+		  {"id": 2, "name": "Jane Doe", "number": "4587654321"},
+		  {"id": 3, "name": "Old Contact", "number": "4511111111"},
+		  {"id": 4, "name": "Unchanged", "number": "4522222222"}
+		]}`)
+	})
+
+	source := []*Contact{
+		{Name: "John Doe", Number: "+45 12 34 56 78"},
+		{Name: "Jane Doe", Number: "87 65 43 21", Email: "jane@example.com"},
+		{Name: "Unchanged", Number: "0045 22 22 22 22"},
+		{Name: "New Contact", Number: "33333333", Email: "new@example.com"},
+		{Name: "Duplicate", Number: "+4533333333"},
+		{Name: "Invalid", Number: "123"},
+	}
+
+	cs := NewContactSync(client.Contacts, &ContactSyncOptions{Delete: true})
+	plan, err := cs.Plan(source)
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+
+	want := &ContactPlan{
+		Changes: []*ContactChange{
+			{
+				Action:   ContactUpdate,
+				Contact:  &Contact{ID: 2, Name: "Jane Doe", Number: "4587654321", Email: "jane@example.com"},
+				Previous: &Contact{ID: 2, Name: "Jane Doe", Number: "4587654321"},
+			},
+			{
+				Action:  ContactCreate,
+				Contact: &Contact{Name: "New Contact", Number: "4533333333", Email: "new@example.com"},
+			},
+			{
+				Action:  ContactDelete,
+				Contact: &Contact{ID: 3, Name: "Old Contact", Number: "4511111111"},
+			},
+		},
+		Skipped: []*SkippedContact{
+			{Contact: source[4], Reason: "duplicate number"},
+			{Contact: source[5], Reason: ErrInvalidPhoneNumber.Error()},
+		},
+	}
+	if !reflect.DeepEqual(plan, want) {
+		t.Errorf("Plan returned %+v, want %+v", plan, want)
+	}
+
+	// without Delete, contacts missing from the source are kept
+	plan, err = NewContactSync(client.Contacts, nil).Plan(source)
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+	if n := plan.Count(ContactDelete); n != 0 {
+		t.Errorf("Plan without Delete returned %d deletes, want 0", n)
+	}
+}
+
+func TestContactSync_Plan_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/contacts", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	})
+
+	if _, err := NewContactSync(client.Contacts, nil).Plan(nil); err == nil {
+		t.Error("Plan expected an error but got none")
+	}
+}
+
+func TestContactPlan_WriteTo(t *testing.T) {
+	plan := &ContactPlan{
+		Changes: []*ContactChange{
+			{Action: ContactCreate, Contact: &Contact{Name: "New", Number: "4533333333", Email: "new@example.com"}},
+			{
+				Action:   ContactUpdate,
+				Contact:  &Contact{ID: 2, Name: "Jane Doe", Number: "4587654321", Email: "jane@example.com"},
+				Previous: &Contact{ID: 2, Name: "Jane", Number: "4587654321"},
+			},
+			{Action: ContactDelete, Contact: &Contact{ID: 3, Name: "Old", Number: "4511111111"}},
+		},
+		Skipped: []*SkippedContact{{Contact: &Contact{Number: "123"}, Reason: "invalid"}},
+	}
+
+	var buf bytes.Buffer
+	n, err := plan.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo returned error: %v", err)
+	}
+
+	want := `create 4533333333: New <new@example.com>
+update 4587654321: Jane <> -> Jane Doe <jane@example.com>
+delete 4511111111: Old <>
+skip "123": invalid
+1 to create, 1 to update, 1 to delete, 1 skipped
+`
+	if got := buf.String(); got != want {
+		t.Errorf("WriteTo wrote %q, want %q", got, want)
+	}
+	if n != int64(len(want)) {
+		t.Errorf("WriteTo returned %d, want %d", n, len(want))
+	}
+}
+
+func TestContactSync_Apply(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var (
+		mu       sync.Mutex
+		requests []string
+		inFlight int
+		maxIn    int
+	)
+	started := make(chan struct{}, 4)
+	release := make(chan struct{})
+	// record holds every request until release is closed, so the number of
+	// requests in flight reaches the concurrency limit.
+	record := func(r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		inFlight++
+		if inFlight > maxIn {
+			maxIn = inFlight
+		}
+		mu.Unlock()
+
+		started <- struct{}{}
+		<-release
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}
+
+	mux.HandleFunc("/contacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		record(r)
+		v := new(firmafonContact)
+		json.NewDecoder(r.Body).Decode(v)
+		if v.Contact.Number == "4544444444" {
+			http.Error(w, `{"message": "invalid"}`, http.StatusUnprocessableEntity)
+			return
+		}
+		v.Contact.ID = 10
+		json.NewEncoder(w).Encode(v)
+	})
+	mux.HandleFunc("/contacts/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		record(r)
+		fmt.Fprint(w, `{"contact": {"id": 2}}`)
+	})
+	mux.HandleFunc("/contacts/3", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		record(r)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	plan := &ContactPlan{Changes: []*ContactChange{
+		{Action: ContactCreate, Contact: &Contact{Name: "New", Number: "4533333333"}},
+		{Action: ContactCreate, Contact: &Contact{Name: "Bad", Number: "4544444444"}},
+		{Action: ContactUpdate, Contact: &Contact{ID: 2, Name: "Jane Doe", Number: "4587654321"}},
+		{Action: ContactDelete, Contact: &Contact{ID: 3, Number: "4511111111"}},
+	}}
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- NewContactSync(client.Contacts, &ContactSyncOptions{Concurrency: 2}).Apply(context.Background(), plan)
+	}()
+	<-started
+	<-started
+	// give Apply the chance to exceed the limit before releasing the handlers
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	err := <-errc
+	mu.Lock()
+	if maxIn != 2 {
+		t.Errorf("Apply had up to %d requests in flight, want 2", maxIn)
+	}
+	mu.Unlock()
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("Apply returned error %v, want an *ErrorResponse", err)
+	}
+	if !strings.Contains(err.Error(), "create 4544444444") {
+		t.Errorf("Apply returned error %q, want it to name the failed change", err)
+	}
+
+	sort.Strings(requests)
+	want := []string{"DELETE /contacts/3", "POST /contacts", "POST /contacts", "PUT /contacts/2"}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("Apply made requests %v, want %v", requests, want)
+	}
+}
+
+func TestContactSync_Apply_canceled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/contacts", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	plan := &ContactPlan{Changes: []*ContactChange{
+		{Action: ContactCreate, Contact: &Contact{Name: "New", Number: "4533333333"}},
+	}}
+	err := NewContactSync(client.Contacts, nil).Apply(ctx, plan)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Apply returned error %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,187 @@
+package firmafon
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestContactsService_List(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/contacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", mediaTypeJSON)
+		if got, want := r.URL.Query(), (url.Values{"q": {"kim"}}); !reflect.DeepEqual(got, want) {
+			t.Errorf("query = %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"contacts": [{"id": 1, "name": "Kim Kontakt", "number": "4512345678", "email": "kimkontakt@example.com"}]}`)
+	})
+
+	contacts, _, err := client.Contacts.List(&ContactsListOptions{Query: "kim"})
+	if err != nil {
+		t.Errorf("Contacts.List returned error: %v", err)
+	}
+
+	want := []*Contact{{ID: 1, Name: "Kim Kontakt", Number: "4512345678", Email: "kimkontakt@example.com"}}
+	if !reflect.DeepEqual(contacts, want) {
+		t.Errorf("Contacts.List returned %+v, want %+v", contacts, want)
+	}
+}
+
+func TestContactsService_SearchByNumber(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/contacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.RawQuery, "number=4512345678"; got != want {
+			t.Errorf("query = %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"contacts": [{"id": 1, "number": "4512345678"}]}`)
+	})
+
+	contacts, _, err := client.Contacts.SearchByNumber("+45 12 34 56 78")
+	if err != nil {
+		t.Errorf("Contacts.SearchByNumber returned error: %v", err)
+	}
+
+	want := []*Contact{{ID: 1, Number: "4512345678"}}
+	if !reflect.DeepEqual(contacts, want) {
+		t.Errorf("Contacts.SearchByNumber returned %+v, want %+v", contacts, want)
+	}
+
+	if _, _, err := client.Contacts.SearchByNumber("123"); err == nil {
+		t.Error("Contacts.SearchByNumber expected an error for an invalid number but got none")
+	}
+}
+
+func TestContactsService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/contacts/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"contact": {"id": 1, "name": "Kim Kontakt"}}`)
+	})
+
+	contact, _, err := client.Contacts.Get(1)
+	if err != nil {
+		t.Errorf("Contacts.Get returned error: %v", err)
+	}
+
+	if want := (&Contact{ID: 1, Name: "Kim Kontakt"}); !reflect.DeepEqual(contact, want) {
+		t.Errorf("Contacts.Get returned %+v, want %+v", contact, want)
+	}
+}
+
+func TestContactsService_Create(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/contacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testHeader(t, r, "Content-Type", mediaTypeJSON)
+		body, _ := io.ReadAll(r.Body)
+		if got, want := strings.TrimSpace(string(body)), `{"contact":{"name":"Kim Kontakt","number":"4512345678"}}`; got != want {
+			t.Errorf("request body = %s, want %s", got, want)
+		}
+		fmt.Fprint(w, `{"contact": {"id": 1, "name": "Kim Kontakt", "number": "4512345678"}}`)
+	})
+
+	contact, _, err := client.Contacts.Create(&Contact{Name: "Kim Kontakt", Number: "4512345678"})
+	if err != nil {
+		t.Errorf("Contacts.Create returned error: %v", err)
+	}
+
+	want := &Contact{ID: 1, Name: "Kim Kontakt", Number: "4512345678"}
+	if !reflect.DeepEqual(contact, want) {
+		t.Errorf("Contacts.Create returned %+v, want %+v", contact, want)
+	}
+}
+
+func TestContactsService_Update(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/contacts/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		body, _ := io.ReadAll(r.Body)
+		if got, want := strings.TrimSpace(string(body)), `{"contact":{"id":1,"email":"kim@example.com"}}`; got != want {
+			t.Errorf("request body = %s, want %s", got, want)
+		}
+		fmt.Fprint(w, `{"contact": {"id": 1, "name": "Kim Kontakt", "email": "kim@example.com"}}`)
+	})
+
+	contact, _, err := client.Contacts.Update(&Contact{ID: 1, Email: "kim@example.com"})
+	if err != nil {
+		t.Errorf("Contacts.Update returned error: %v", err)
+	}
+
+	want := &Contact{ID: 1, Name: "Kim Kontakt", Email: "kim@example.com"}
+	if !reflect.DeepEqual(contact, want) {
+		t.Errorf("Contacts.Update returned %+v, want %+v", contact, want)
+	}
+}
+
+func TestContactsService_Delete(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/contacts/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := client.Contacts.Delete(1); err != nil {
+		t.Errorf("Contacts.Delete returned error: %v", err)
+	}
+}
+
+func TestContactsService_Import(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/contacts/import", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		body, _ := io.ReadAll(r.Body)
+		if got, want := strings.TrimSpace(string(body)), `{"contacts":[{"name":"A","number":"4511111111"},{"name":"B","number":"4522222222"}]}`; got != want {
+			t.Errorf("request body = %s, want %s", got, want)
+		}
+		fmt.Fprint(w, `{"contacts": [{"id": 1, "name": "A", "number": "4511111111"}, {"id": 2, "name": "B", "number": "4522222222"}]}`)
+	})
+
+	contacts, _, err := client.Contacts.Import([]*Contact{
+		{Name: "A", Number: "4511111111"},
+		{Name: "B", Number: "4522222222"},
+	})
+	if err != nil {
+		t.Errorf("Contacts.Import returned error: %v", err)
+	}
+
+	want := []*Contact{
+		{ID: 1, Name: "A", Number: "4511111111"},
+		{ID: 2, Name: "B", Number: "4522222222"},
+	}
+	if !reflect.DeepEqual(contacts, want) {
+		t.Errorf("Contacts.Import returned %+v, want %+v", contacts, want)
+	}
+}
+
+func TestContactsService_Create_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/contacts", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Unprocessable Entity", http.StatusUnprocessableEntity)
+	})
+
+	if _, _, err := client.Contacts.Create(&Contact{}); err == nil {
+		t.Error("Contacts.Create expected an error but got none")
+	}
+}
@@ -0,0 +1,106 @@
+package firmafon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// Credential is a secret such as an access token. It formats as "REDACTED"
+// with fmt, including the %+v and %#v verbs, and marshals to "REDACTED" as
+// JSON, so logging a Client or a struct holding a Credential does not leak
+// it. Use Value to get the secret.
+type Credential string
+
+// Value returns the secret.
+func (c Credential) Value() string {
+	return string(c)
+}
+
+// String returns "REDACTED", or "" for an empty credential.
+func (c Credential) String() string {
+	if c == "" {
+		return ""
+	}
+	return redacted
+}
+
+// GoString implements fmt.GoStringer.
+func (c Credential) GoString() string {
+	return fmt.Sprintf("firmafon.Credential(%q)", c.String())
+}
+
+// Format implements fmt.Formatter so the secret is redacted for every verb.
+func (c Credential) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		io.WriteString(f, c.GoString())
+		return
+	}
+	io.WriteString(f, c.String())
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c Credential) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("%q", c.String())), nil
+}
+
+// TokenProvider provides the access token for a request, e.g. from a secret
+// manager. Token is called for every request with the request's context and
+// should cache the token if fetching it is expensive.
+type TokenProvider interface {
+	Token(ctx context.Context) (Credential, error)
+}
+
+// TokenProviderFunc is an adapter to allow the use of ordinary functions as a
+// TokenProvider.
+type TokenProviderFunc func(ctx context.Context) (Credential, error)
+
+// Token calls f(ctx).
+func (f TokenProviderFunc) Token(ctx context.Context) (Credential, error) {
+	return f(ctx)
+}
+
+// NewClientWithTokenProvider returns a new client that authenticates with the
+// token returned by p for each request instead of a static access token.
+func NewClientWithTokenProvider(p TokenProvider) *Client {
+	c := NewClient("")
+	c.tokens = p
+
+	return c
+}
+
+// tokenSourceProvider adapts an oauth2.TokenSource to a TokenProvider.
+type tokenSourceProvider struct {
+	ts oauth2.TokenSource
+}
+
+func (p tokenSourceProvider) Token(ctx context.Context) (Credential, error) {
+	tok, err := p.ts.Token()
+	if err != nil {
+		return "", err
+	}
+
+	return Credential(tok.AccessToken), nil
+}
+
+// redactToken replaces the bearer token of req in s, e.g. an error message
+// echoed by the API.
+func redactToken(s string, req *http.Request) string {
+	if req == nil {
+		return s
+	}
+
+	auth := req.Header.Get("Authorization")
+	if i := strings.IndexByte(auth, ' '); i >= 0 {
+		auth = auth[i+1:]
+	}
+	if auth == "" {
+		return s
+	}
+
+	return strings.ReplaceAll(s, auth, redacted)
+}
@@ -1,25 +1,126 @@
 package firmafon
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 )
 
 type EmployeesService service
 
 type Employee struct {
-	Admin            bool        `json:"admin,omitempty"`
-	CloakReception   interface{} `json:"cloak_reception,omitempty"`
-	CompanyID        int         `json:"company_id,omitempty"`
-	DndTimeoutAt     *time.Time  `json:"dnd_timeout_at,omitempty"`
-	DoNotDisturb     bool        `json:"do_not_disturb,omitempty"`
-	EmployeeGroupIds []int       `json:"employee_group_ids,omitempty"`
-	Features         []string    `json:"features,omitempty"`
-	ID               int         `json:"id,omitempty"`
-	LivePresence     string      `json:"live_presence,omitempty"`
-	Name             string      `json:"name,omitempty"`
-	Number           string      `json:"number,omitempty"`
-	SpeedDial        *SpeedDial  `json:"speed_dial,omitempty"`
+	Admin            bool            `json:"admin,omitempty"`
+	CloakReception   *CloakReception `json:"cloak_reception,omitempty"`
+	CompanyID        int             `json:"company_id,omitempty"`
+	DndTimeoutAt     *time.Time      `json:"dnd_timeout_at,omitempty"`
+	DoNotDisturb     bool            `json:"do_not_disturb,omitempty"`
+	EmployeeGroupIds []int           `json:"employee_group_ids,omitempty"`
+	Features         Features        `json:"features,omitempty"`
+	ID               int             `json:"id,omitempty"`
+	LivePresence     string          `json:"live_presence,omitempty"`
+	Name             string          `json:"name,omitempty"`
+	Number           string          `json:"number,omitempty"`
+	SpeedDial        *SpeedDial      `json:"speed_dial,omitempty"`
+}
+
+// CloakReception reports whether an employee is signed into the reception,
+// see ReceptionService. The API sends it as a boolean, or as the number of
+// the reception when the company has more than one. An empty string and 0
+// mean disabled.
+type CloakReception struct {
+	Enabled bool
+
+	// Number is the number of the reception, if the API sent one.
+	Number string
+
+	// raw holds any value the API sent that is not a boolean, so it is sent
+	// back unchanged unless Enabled or Number are changed.
+	raw json.RawMessage
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *CloakReception) UnmarshalJSON(data []byte) error {
+	*c = CloakReception{}
+
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return err
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case bool:
+		c.Enabled = v
+		return nil
+	case string:
+		c.Enabled = v != ""
+		c.Number = v
+	case json.Number:
+		if f, err := v.Float64(); err != nil || f != 0 {
+			c.Enabled = true
+			c.Number = v.String()
+		}
+	default:
+		c.Enabled = true
+	}
+	c.raw = append(json.RawMessage(nil), data...)
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. A value decoded from the API is sent
+// back as it was received unless it was changed, and one that is not enabled
+// is sent as false.
+func (c CloakReception) MarshalJSON() ([]byte, error) {
+	if c.raw != nil {
+		var orig CloakReception
+		if err := orig.UnmarshalJSON(c.raw); err == nil &&
+			orig.Enabled == c.Enabled && orig.Number == c.Number {
+			return c.raw, nil
+		}
+	}
+
+	switch {
+	case !c.Enabled:
+		return []byte("false"), nil
+	case c.Number != "":
+		return []byte(strconv.Quote(c.Number)), nil
+	default:
+		return []byte("true"), nil
+	}
+}
+
+// Features the API is known to return in Employee.Features. Other values are
+// kept as they are.
+const (
+	FeatureCallRecording Feature = "call_recording"
+	FeatureMobile        Feature = "mobile"
+	FeatureReception     Feature = "reception"
+	FeatureSMS           Feature = "sms"
+	FeatureVoicemail     Feature = "voicemail"
+)
+
+// Feature is a capability enabled for an employee.
+type Feature string
+
+// Features is the set of capabilities enabled for an employee, in the order
+// returned by the API.
+type Features []Feature
+
+// Has reports whether f is in the set.
+func (fs Features) Has(f Feature) bool {
+	for _, v := range fs {
+		if v == f {
+			return true
+		}
+	}
+
+	return false
 }
 
 type SpeedDial struct {
@@ -44,17 +145,27 @@ type firmafonSMSBody struct {
 	Body string `json:"body"`
 }
 
-type firmafonSMSResponse struct {
+// SMSResponse is the result of sending an SMS.
+type SMSResponse struct {
 	Sent int `json:"sent"`
 }
 
 // All returns a slice of all employees
 func (s *EmployeesService) All() ([]*Employee, *Response, error) {
+	if ec := s.client.employeeCache; ec != nil {
+		return ec.getAllEmployees(s.all)
+	}
+
+	return s.all()
+}
+
+func (s *EmployeesService) all() ([]*Employee, *Response, error) {
 	url := "employees"
 	req, err := s.client.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, nil, err
 	}
+	req = withOperation(req, "Employees", "All")
 
 	var emps *firmafonEmployees
 	resp, err := s.client.Do(req, &emps)
@@ -67,11 +178,20 @@ func (s *EmployeesService) All() ([]*Employee, *Response, error) {
 
 // GetById returns the employee with the specified ID
 func (s *EmployeesService) GetById(id int) (*Employee, *Response, error) {
+	if ec := s.client.employeeCache; ec != nil {
+		return ec.getByID(id, s.getByID)
+	}
+
+	return s.getByID(id)
+}
+
+func (s *EmployeesService) getByID(id int) (*Employee, *Response, error) {
 	url := fmt.Sprintf("employees/%d", id)
 	req, err := s.client.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, nil, err
 	}
+	req = withOperation(req, "Employees", "GetById")
 
 	var e *firmafonEmployee
 	resp, err := s.client.Do(req, &e)
@@ -90,9 +210,13 @@ func (s *EmployeesService) Update(e *Employee) (*Employee, *Response, error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	req = withOperation(req, "Employees", "Update")
 
 	emp := new(firmafonEmployee)
 	resp, err := s.client.Do(req, &emp)
+	if ec := s.client.employeeCache; ec != nil {
+		ec.invalidate(e.ID)
+	}
 	if err != nil {
 		return nil, resp, err
 	}
@@ -107,6 +231,7 @@ func (s *EmployeesService) Authenticated() (*Employee, *Response, error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	req = withOperation(req, "Employees", "Authenticated")
 
 	emp := new(firmafonEmployee)
 	resp, err := s.client.Do(req, &emp)
@@ -120,8 +245,8 @@ func (s *EmployeesService) Authenticated() (*Employee, *Response, error) {
 // Send an SMS message to the given employee.
 // The sender will be shown as either the authenticated employee’s number or name.
 // Beware these are cheap, but not free see https://www.firmafon.dk/prisliste
-// This feature is not available for companies in trial.
-func (s *EmployeesService) SendSMS(e *Employee, msg string) (*firmafonSMSResponse, *Response, error) {
+// This feature is not available for companies in trial, see CompanyService.Subscription.
+func (s *EmployeesService) SendSMS(e *Employee, msg string) (*SMSResponse, *Response, error) {
 	url := fmt.Sprintf("employees/%d/message", e.ID)
 
 	body := &firmafonSMSBody{Body: msg}
@@ -131,8 +256,9 @@ func (s *EmployeesService) SendSMS(e *Employee, msg string) (*firmafonSMSRespons
 	if err != nil {
 		return nil, nil, err
 	}
+	req = withOperation(req, "Employees", "SendSMS")
 
-	data := &firmafonSMSResponse{}
+	data := &SMSResponse{}
 	resp, err := s.client.Do(req, &data)
 	if err != nil {
 		return nil, resp, err
@@ -0,0 +1,323 @@
+package firmafon
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultEmployeeCacheTTL        = time.Minute
+	defaultEmployeeCacheMaxEntries = 1000
+)
+
+// EmployeeCacheOptions specifies the optional parameters to
+// Client.EnableEmployeeCache.
+type EmployeeCacheOptions struct {
+	// TTL is how long an employee is cached. Defaults to one minute.
+	TTL time.Duration
+
+	// MaxEntries bounds the number of employees cached by GetById. The least
+	// recently used employee is evicted first. Defaults to 1000.
+	MaxEntries int
+}
+
+// EnableEmployeeCache makes EmployeesService.GetById and EmployeesService.All
+// serve results from memory until they expire. Concurrent lookups of the same
+// employee share a single request and EmployeesService.Update invalidates the
+// updated employee. Results served from the cache have a *Response with
+// status 200 OK and Cached set, and no request is sent.
+// opt may be nil. It must not be called concurrently with requests.
+func (c *Client) EnableEmployeeCache(opt *EmployeeCacheOptions) {
+	if opt == nil {
+		opt = &EmployeeCacheOptions{}
+	}
+
+	ec := &employeeCache{
+		ttl:     opt.TTL,
+		max:     opt.MaxEntries,
+		now:     time.Now,
+		entries: make(map[int]*list.Element),
+		lru:     list.New(),
+	}
+	if ec.ttl <= 0 {
+		ec.ttl = defaultEmployeeCacheTTL
+	}
+	if ec.max <= 0 {
+		ec.max = defaultEmployeeCacheMaxEntries
+	}
+
+	c.employeeCache = ec
+}
+
+// DisableEmployeeCache turns off and drops the cache enabled by
+// EnableEmployeeCache.
+func (c *Client) DisableEmployeeCache() {
+	c.employeeCache = nil
+}
+
+type employeeCacheEntry struct {
+	employee *Employee
+	expires  time.Time
+}
+
+type employeeCache struct {
+	ttl time.Duration
+	max int
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[int]*list.Element
+	lru     *list.List
+	all     []*Employee
+	allExp  time.Time
+	// gen is bumped on every invalidation so lookups that were in flight
+	// while an employee was updated don't store stale data.
+	gen uint64
+
+	flight flightGroup
+}
+
+func (ec *employeeCache) get(id int) (*Employee, bool) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	el, ok := ec.entries[id]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*employeeCacheEntry)
+	if !ec.now().Before(entry.expires) {
+		ec.lru.Remove(el)
+		delete(ec.entries, id)
+		return nil, false
+	}
+	ec.lru.MoveToFront(el)
+
+	return entry.employee.clone(), true
+}
+
+func (ec *employeeCache) set(gen uint64, emps ...*Employee) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	if gen != ec.gen {
+		return
+	}
+	ec.add(emps...)
+}
+
+func (ec *employeeCache) add(emps ...*Employee) {
+	expires := ec.now().Add(ec.ttl)
+	for _, e := range emps {
+		if e == nil {
+			continue
+		}
+		entry := &employeeCacheEntry{employee: e.clone(), expires: expires}
+		if el, ok := ec.entries[e.ID]; ok {
+			el.Value = entry
+			ec.lru.MoveToFront(el)
+			continue
+		}
+		ec.entries[e.ID] = ec.lru.PushFront(entry)
+	}
+
+	for ec.lru.Len() > ec.max {
+		el := ec.lru.Back()
+		ec.lru.Remove(el)
+		delete(ec.entries, el.Value.(*employeeCacheEntry).employee.ID)
+	}
+}
+
+func (ec *employeeCache) getAll() ([]*Employee, bool) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	if ec.all == nil || !ec.now().Before(ec.allExp) {
+		return nil, false
+	}
+
+	return cloneEmployees(ec.all), true
+}
+
+func (ec *employeeCache) setAll(gen uint64, emps []*Employee) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	if gen != ec.gen {
+		return
+	}
+	ec.all = cloneEmployees(emps)
+	ec.allExp = ec.now().Add(ec.ttl)
+	ec.add(emps...)
+}
+
+func (ec *employeeCache) generation() uint64 {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	return ec.gen
+}
+
+// invalidate drops the employee with the given ID and the cached list of all
+// employees.
+func (ec *employeeCache) invalidate(id int) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	ec.gen++
+	ec.all = nil
+	if el, ok := ec.entries[id]; ok {
+		ec.lru.Remove(el)
+		delete(ec.entries, id)
+	}
+}
+
+func (ec *employeeCache) getByID(id int, fetch func(int) (*Employee, *Response, error)) (*Employee, *Response, error) {
+	if e, ok := ec.get(id); ok {
+		return e, cachedResponse(), nil
+	}
+
+	res := ec.flight.do("employee/"+strconv.Itoa(id), func() flightResult {
+		gen := ec.generation()
+		e, resp, err := fetch(id)
+		if err == nil {
+			ec.set(gen, e)
+		}
+		return flightResult{val: e, resp: resp, err: err}
+	})
+	e, _ := res.val.(*Employee)
+
+	return e.clone(), res.resp, res.err
+}
+
+func (ec *employeeCache) getAllEmployees(fetch func() ([]*Employee, *Response, error)) ([]*Employee, *Response, error) {
+	if emps, ok := ec.getAll(); ok {
+		return emps, cachedResponse(), nil
+	}
+
+	res := ec.flight.do("employees", func() flightResult {
+		gen := ec.generation()
+		emps, resp, err := fetch()
+		if err == nil {
+			ec.setAll(gen, emps)
+		}
+		return flightResult{val: emps, resp: resp, err: err}
+	})
+	emps, _ := res.val.([]*Employee)
+
+	return cloneEmployees(emps), res.resp, res.err
+}
+
+// cachedResponse returns the response for a result served from the cache.
+func cachedResponse() *Response {
+	return &Response{
+		Response: &http.Response{
+			Status:     "200 OK",
+			StatusCode: http.StatusOK,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     make(http.Header),
+			Body:       http.NoBody,
+		},
+		Cached: true,
+	}
+}
+
+// clone returns a copy of e that shares no memory with e, so cached
+// employees can't be modified by callers.
+func (e *Employee) clone() *Employee {
+	if e == nil {
+		return nil
+	}
+
+	c := *e
+	if e.DndTimeoutAt != nil {
+		t := *e.DndTimeoutAt
+		c.DndTimeoutAt = &t
+	}
+	if e.SpeedDial != nil {
+		sd := *e.SpeedDial
+		c.SpeedDial = &sd
+	}
+	if e.CloakReception != nil {
+		cr := *e.CloakReception
+		c.CloakReception = &cr
+	}
+	c.EmployeeGroupIds = append([]int(nil), e.EmployeeGroupIds...)
+	c.Features = append(Features(nil), e.Features...)
+
+	return &c
+}
+
+func cloneEmployees(emps []*Employee) []*Employee {
+	if emps == nil {
+		return nil
+	}
+
+	c := make([]*Employee, len(emps))
+	for i, e := range emps {
+		c[i] = e.clone()
+	}
+
+	return c
+}
+
+type flightResult struct {
+	val  interface{}
+	resp *Response
+	err  error
+}
+
+type flightCall struct {
+	wg  sync.WaitGroup
+	res flightResult
+}
+
+// flightGroup de-duplicates concurrent calls with the same key so only the
+// first one does the work and the rest wait for its result.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// do calls fn unless a call with the same key is in flight, in which case it
+// waits for that call's result. The first caller's request carries its
+// context, so if it fails because that context was cancelled or timed out,
+// waiters call their own fn rather than return an error that isn't theirs.
+func (g *flightGroup) do(key string, fn func() flightResult) flightResult {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		if isContextError(c.res.err) {
+			return fn()
+		}
+		return c.res
+	}
+	c := new(flightCall)
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.res = fn()
+	c.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	return c.res
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
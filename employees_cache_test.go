@@ -0,0 +1,249 @@
+package firmafon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEmployeesService_GetById_Cache(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var requests int32
+	mux.HandleFunc("/employees/1", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"employee":{"id": 1, "name": "Steffen", "employee_group_ids": [1]}}`)
+		case "PUT":
+			fmt.Fprint(w, `{"employee":{"id": 1, "name": "Steffen Updated"}}`)
+		}
+	})
+
+	client.EnableEmployeeCache(&EmployeeCacheOptions{TTL: time.Minute})
+	now := time.Date(2014, 3, 21, 13, 0, 0, 0, time.UTC)
+	client.employeeCache.now = func() time.Time { return now }
+
+	emp, resp, err := client.Employees.GetById(1)
+	if err != nil {
+		t.Fatalf("GetById returned error: %v", err)
+	}
+	if resp == nil {
+		t.Error("GetById returned a nil response on a cache miss")
+	}
+
+	// modifying the result must not modify the cache
+	emp.EmployeeGroupIds[0] = 42
+
+	emp, resp, err = client.Employees.GetById(1)
+	if err != nil {
+		t.Fatalf("GetById returned error: %v", err)
+	}
+	if resp == nil || resp.Response == nil {
+		t.Fatal("GetById returned a nil response on a cache hit")
+	}
+	if !resp.Cached || resp.StatusCode != http.StatusOK {
+		t.Errorf("GetById returned Cached = %v, StatusCode = %d on a cache hit, want true, %d", resp.Cached, resp.StatusCode, http.StatusOK)
+	}
+	want := &Employee{ID: 1, Name: "Steffen", EmployeeGroupIds: []int{1}}
+	if !reflect.DeepEqual(emp, want) {
+		t.Errorf("GetById returned %+v, want %+v", emp, want)
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("API was called %d times, want 1", got)
+	}
+
+	now = now.Add(time.Minute)
+	client.Employees.GetById(1)
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("API was called %d times after the TTL expired, want 2", got)
+	}
+
+	if _, _, err := client.Employees.Update(&Employee{ID: 1, Name: "Steffen Updated"}); err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	client.Employees.GetById(1)
+	if got := atomic.LoadInt32(&requests); got != 4 {
+		t.Errorf("API was called %d times after Update, want 4", got)
+	}
+
+	client.DisableEmployeeCache()
+	client.Employees.GetById(1)
+	if got := atomic.LoadInt32(&requests); got != 5 {
+		t.Errorf("API was called %d times with the cache disabled, want 5", got)
+	}
+}
+
+func TestEmployeesService_GetById_CacheSingleflight(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var requests int32
+	release := make(chan struct{})
+	mux.HandleFunc("/employees/1", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		fmt.Fprint(w, `{"employee":{"id": 1}}`)
+	})
+
+	client.EnableEmployeeCache(nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			emp, _, err := client.Employees.GetById(1)
+			if err != nil || emp == nil || emp.ID != 1 {
+				t.Errorf("GetById returned %+v, %v", emp, err)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("API was called %d times for concurrent lookups, want 1", got)
+	}
+}
+
+func TestEmployeesService_GetById_CacheSingleflightCancel(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var requests int32
+	started := make(chan struct{})
+	mux.HandleFunc("/employees/1", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			// hold the first request until its caller gives up
+			close(started)
+			<-r.Context().Done()
+			return
+		}
+		fmt.Fprint(w, `{"employee":{"id": 1}}`)
+	})
+
+	client.EnableEmployeeCache(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, _, err := client.WithContext(ctx).Employees.GetById(1)
+		leaderErr <- err
+	}()
+	<-started
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		emp, _, err := client.Employees.GetById(1)
+		if err != nil || emp == nil || emp.ID != 1 {
+			t.Errorf("GetById returned %+v, %v after another caller was cancelled", emp, err)
+		}
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("GetById returned %v for the cancelled caller, want context.Canceled", err)
+	}
+	<-done
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("API was called %d times, want 2", got)
+	}
+}
+
+func TestEmployeesService_GetById_CacheMaxEntries(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var requests int32
+	for id := 1; id <= 3; id++ {
+		id := id
+		mux.HandleFunc(fmt.Sprintf("/employees/%d", id), func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			fmt.Fprintf(w, `{"employee":{"id": %d}}`, id)
+		})
+	}
+
+	client.EnableEmployeeCache(&EmployeeCacheOptions{MaxEntries: 2})
+
+	client.Employees.GetById(1)
+	client.Employees.GetById(2)
+	client.Employees.GetById(1) // 1 is now the most recently used
+	client.Employees.GetById(3) // evicts 2
+	client.Employees.GetById(1)
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Errorf("API was called %d times, want 3", got)
+	}
+	client.Employees.GetById(2)
+	if got := atomic.LoadInt32(&requests); got != 4 {
+		t.Errorf("API was called %d times after eviction, want 4", got)
+	}
+}
+
+func TestEmployeesService_All_Cache(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var requests int32
+	mux.HandleFunc("/employees", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprint(w, `{"employees":[{"id": 1}, {"id": 2}]}`)
+	})
+	mux.HandleFunc("/employees/2", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprint(w, `{"employee":{"id": 2}}`)
+	})
+
+	client.EnableEmployeeCache(nil)
+
+	for i := 0; i < 2; i++ {
+		emps, resp, err := client.Employees.All()
+		if err != nil {
+			t.Fatalf("All returned error: %v", err)
+		}
+		if resp == nil || resp.Response == nil {
+			t.Fatalf("request %d: All returned a nil response", i)
+		}
+		if got, want := resp.Cached, i == 1; got != want {
+			t.Errorf("request %d: Cached = %v, want %v", i, got, want)
+		}
+		if want := []*Employee{{ID: 1}, {ID: 2}}; !reflect.DeepEqual(emps, want) {
+			t.Errorf("All returned %+v, want %+v", emps, want)
+		}
+	}
+
+	// All populates the per-employee cache too
+	if _, _, err := client.Employees.GetById(2); err != nil {
+		t.Fatalf("GetById returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("API was called %d times, want 1", got)
+	}
+}
+
+func TestEmployeesService_GetById_CacheError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/employees/1", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	})
+
+	client.EnableEmployeeCache(nil)
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Employees.GetById(1); err == nil {
+			t.Error("GetById expected an error but got none")
+		}
+	}
+}
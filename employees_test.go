@@ -1,6 +1,7 @@
 package firmafon
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -297,3 +298,132 @@ func TestEmployeesService_SendSMS_Invalid_URL(t *testing.T) {
 		t.Error("SendSMS expected error to be returned but gone none")
 	}
 }
+
+func TestEmployeesService_GetById_cloakReceptionAndFeatures(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/employees/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"employee": {"id": 1, "cloak_reception": true, "features": ["sms", "voicemail", "beta_dashboard"]}}`)
+	})
+
+	emp, _, err := client.Employees.GetById(1)
+	if err != nil {
+		t.Fatalf("Get employee by id returned error: %v", err)
+	}
+
+	want := &Employee{
+		ID:             1,
+		CloakReception: &CloakReception{Enabled: true},
+		Features:       Features{FeatureSMS, FeatureVoicemail, "beta_dashboard"},
+	}
+	if !reflect.DeepEqual(emp, want) {
+		t.Errorf("Get employee by id returned %+v, want %+v", emp, want)
+	}
+}
+
+func TestCloakReception_JSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want CloakReception
+	}{
+		{`true`, CloakReception{Enabled: true}},
+		{`false`, CloakReception{}},
+		{`null`, CloakReception{}},
+		{`""`, CloakReception{raw: json.RawMessage(`""`)}},
+		{`0`, CloakReception{raw: json.RawMessage(`0`)}},
+		{`"4571999999"`, CloakReception{Enabled: true, Number: "4571999999", raw: json.RawMessage(`"4571999999"`)}},
+		{`4571999999`, CloakReception{Enabled: true, Number: "4571999999", raw: json.RawMessage(`4571999999`)}},
+		{`{"id":3}`, CloakReception{Enabled: true, raw: json.RawMessage(`{"id":3}`)}},
+	}
+
+	for _, tt := range tests {
+		var got CloakReception
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Errorf("Unmarshal(%s) returned error: %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Unmarshal(%s) returned %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+
+	// values are sent back the way the API sent them
+	for _, in := range []string{`true`, `false`, `0`, `""`, `"4571999999"`, `4571999999`, `{"id":3}`} {
+		var c CloakReception
+		json.Unmarshal([]byte(in), &c)
+		out, err := json.Marshal(c)
+		if err != nil {
+			t.Errorf("Marshal(%s) returned error: %v", in, err)
+		}
+		if string(out) != in {
+			t.Errorf("Marshal(%s) returned %s", in, out)
+		}
+	}
+
+	out, _ := json.Marshal(CloakReception{Number: "4571999999"})
+	if want := `false`; string(out) != want {
+		t.Errorf("Marshal of a disabled CloakReception returned %s, want %s", out, want)
+	}
+
+	// changed values are sent as the fields say
+	changes := []struct {
+		in     string
+		change func(*CloakReception)
+		want   string
+	}{
+		{`0`, func(c *CloakReception) { c.Enabled, c.Number = true, "4571999999" }, `"4571999999"`},
+		{`""`, func(c *CloakReception) { c.Enabled = true }, `true`},
+		{`4571999999`, func(c *CloakReception) { c.Enabled = false }, `false`},
+		{`{"id":3}`, func(c *CloakReception) { c.Number = "4571999999" }, `"4571999999"`},
+	}
+	for _, tt := range changes {
+		var c CloakReception
+		json.Unmarshal([]byte(tt.in), &c)
+		tt.change(&c)
+		if out, _ := json.Marshal(c); string(out) != tt.want {
+			t.Errorf("Marshal of changed %s returned %s, want %s", tt.in, out, tt.want)
+		}
+	}
+}
+
+func TestEmployee_JSONRoundTrip(t *testing.T) {
+	in := `{"cloak_reception":{"id":3,"name":"Support"},"features":["sms","beta_dashboard"],"id":1}`
+
+	e := new(Employee)
+	if err := json.Unmarshal([]byte(in), e); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	out, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	if string(out) != in {
+		t.Errorf("Marshal returned %s, want %s", out, in)
+	}
+
+	// a nil CloakReception is left out so Update doesn't change it
+	out, _ = json.Marshal(&Employee{ID: 1})
+	if want := `{"id":1}`; string(out) != want {
+		t.Errorf("Marshal returned %s, want %s", out, want)
+	}
+}
+
+func TestFeatures_Has(t *testing.T) {
+	fs := Features{FeatureSMS, "beta_dashboard"}
+
+	tests := map[Feature]bool{
+		FeatureSMS:       true,
+		"beta_dashboard": true,
+		FeatureVoicemail: false,
+	}
+	for f, want := range tests {
+		if got := fs.Has(f); got != want {
+			t.Errorf("Has(%q) returned %v, want %v", f, got, want)
+		}
+	}
+
+	if Features(nil).Has(FeatureSMS) {
+		t.Errorf("Has on nil Features returned true, want false")
+	}
+}
@@ -2,6 +2,7 @@ package firmafon
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -22,15 +23,39 @@ const (
 
 // A Client manages communication with the Firmafon API.
 type Client struct {
-	AccessToken string
+	// AccessToken authenticates requests unless the client was created with
+	// a TokenProvider or an oauth2.TokenSource. It is redacted when the
+	// client is printed.
+	AccessToken Credential
 	client      *http.Client
 	BaseURL     *url.URL
 
+	// HTTPCache enables conditional requests when set. GET responses carrying
+	// an ETag or Last-Modified header are stored, and later requests for the
+	// same URL send If-None-Match/If-Modified-Since so an unchanged resource
+	// is served from the cache when the API responds 304 Not Modified.
+	// Only JSON responses are cached, not bodies written to an io.Writer.
+	// The decoded values of recent responses are also kept in memory, so a
+	// 304 is served without decoding the stored body again.
+	HTTPCache HTTPCache
+	decoded   *decodedCache
+
 	common service
 
+	tokens        TokenProvider
+	employeeCache *employeeCache
+	middleware    []Middleware
+	ctx           context.Context
+
 	// Services used for talking to different parts of the Firmafon API
-	Employees *EmployeesService
-	Calls     *CallsService
+	Employees    *EmployeesService
+	Calls        *CallsService
+	Company      *CompanyService
+	Numbers      *NumbersService
+	Voicemails   *VoicemailsService
+	Contacts     *ContactsService
+	Reception    *ReceptionService
+	OpeningHours *OpeningHoursService
 }
 
 type service struct {
@@ -38,19 +63,24 @@ type service struct {
 }
 
 type CallsListOptions struct {
-	Endpoint        string `url:"endpoint"`
-	Direction       string `url:"direction"`
-	Status          string `url:"status"`
-	Number          string `url:"number"`
-	Limit           string `url:"limit"`
-	StartedAtGtOrEq string `url:"started_at_gt_or_eq"`
-	StartedAtLtOrEq string `url:"started_at_lt_or_eq"`
-	EndedAtGtOrEq   string `url:"ended_at_gt_or_eq"`
-	EndedAtLtOrEq   string `url:"ended_at_lt_or_eq"`
+	Endpoint        string      `url:"endpoint"`
+	Direction       string      `url:"direction"`
+	Status          string      `url:"status"`
+	Number          PhoneNumber `url:"number"`
+	Limit           string      `url:"limit"`
+	StartedAtGtOrEq string      `url:"started_at_gt_or_eq"`
+	StartedAtLtOrEq string      `url:"started_at_lt_or_eq"`
+	EndedAtGtOrEq   string      `url:"ended_at_gt_or_eq"`
+	EndedAtLtOrEq   string      `url:"ended_at_lt_or_eq"`
 }
 
 type Response struct {
 	*http.Response
+
+	// Cached is true when the API responded 304 Not Modified and the body was
+	// served from Client.HTTPCache, or when the result was served from the
+	// employee cache without a request, see Client.EnableEmployeeCache.
+	Cached bool
 }
 
 type ErrorResponse struct {
@@ -66,13 +96,33 @@ func (r *AuthError) Error() string { return (*ErrorResponse)(r).Error() }
 func (r *ErrorResponse) Error() string {
 	return fmt.Sprintf("%v %v: %d %v",
 		r.Response.Request.Method, sanitizeURL(r.Response.Request.URL),
-		r.Response.StatusCode, r.Message)
+		r.Response.StatusCode, redactToken(r.Message, r.Response.Request))
 }
 
 func NewClient(token string) *Client {
-	httpClient := http.DefaultClient
+	return NewClientWithHTTPClient(token, nil)
+}
+
+// NewClientWithHTTPClient returns a new client that sends requests with
+// httpClient, e.g. one with a custom transport. If httpClient is nil,
+// http.DefaultClient is used.
+func NewClientWithHTTPClient(token string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
 	baseURL, _ := url.Parse(defaultBaseURL)
-	c := &Client{client: httpClient, BaseURL: baseURL, AccessToken: token}
+	c := &Client{
+		client:      httpClient,
+		BaseURL:     baseURL,
+		AccessToken: Credential(token),
+		decoded:     newDecodedCache(defaultDecodedCacheEntries),
+	}
+	c.initServices()
+
+	return c
+}
+
+func (c *Client) initServices() {
 	c.common.client = c
 	c.Employees = (*EmployeesService)(&c.common)
 	callSrv := &CallsService{
@@ -80,8 +130,25 @@ func NewClient(token string) *Client {
 		Endpoint: "calls",
 	}
 	c.Calls = callSrv
+	c.Company = (*CompanyService)(&c.common)
+	c.Numbers = (*NumbersService)(&c.common)
+	c.Voicemails = (*VoicemailsService)(&c.common)
+	c.Contacts = (*ContactsService)(&c.common)
+	c.Reception = (*ReceptionService)(&c.common)
+	c.OpeningHours = (*OpeningHoursService)(&c.common)
+}
 
-	return c
+// WithContext returns a shallow copy of c whose requests carry ctx, so they
+// are cancelled with it and middleware can read values such as the current
+// trace span from it. Caches and middleware are shared with c.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c2 := new(Client)
+	*c2 = *c
+	c2.ctx = ctx
+	c2.initServices()
+	c2.Calls.Endpoint = c.Calls.Endpoint
+
+	return c2
 }
 
 // addOptions adds the parameters in opt as URL query parameters to s. opt
@@ -106,41 +173,135 @@ func addOptions(s string, opt interface{}) (string, error) {
 	return u.String(), nil
 }
 
+// Do sends req through the client's middleware and decodes the response
+// into v. If v is an io.Writer the body is copied to it instead.
 func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
+	return c.chain()(req, v)
+}
+
+func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
+	var (
+		cacheKey string
+		cached   *HTTPCacheEntry
+	)
+	if cacheable(v) {
+		cacheKey, cached = c.conditional(req)
+	}
+
 	resp, err := c.client.Do(req)
 	if err != nil {
+		// the URL in the error may carry an access token
+		if e, ok := err.(*url.Error); ok {
+			if u, perr := url.Parse(e.URL); perr == nil {
+				e.URL = sanitizeURL(u).String()
+			}
+		}
 		return nil, err
 	}
 
 	response := newResponse(resp)
 
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		response.Cached = true
+		if c.decoded.load(cacheKey, cached, v) {
+			return response, nil
+		}
+		if err := decodeBody(bytes.NewReader(cached.Body), v); err != nil {
+			return response, err
+		}
+		c.decoded.store(cacheKey, cached, v)
+		return response, nil
+	}
+
 	err = CheckResponse(resp)
 	if err != nil {
 		return response, err
 	}
 
+	if o, ok := v.(responseObserver); ok {
+		o.observeResponse(resp)
+	}
+
 	defer func() {
 		resp.Body.Close()
 	}()
 
-	if v != nil {
-		if w, ok := v.(io.Writer); ok {
-			_, err = io.Copy(w, resp.Body)
+	if cacheKey != "" {
+		if entry := newHTTPCacheEntry(resp); entry != nil {
+			entry.Body, err = ioutil.ReadAll(resp.Body)
 			if err != nil {
 				return nil, err
 			}
-		} else {
-			err = json.NewDecoder(resp.Body).Decode(v)
-			if err == io.EOF {
-				err = nil // ignore EOF errors caused by empty response body
+			// only cache bodies that decode, or every 304 would fail again
+			if err := decodeBody(bytes.NewReader(entry.Body), v); err != nil {
+				return response, err
 			}
+			c.HTTPCache.Set(cacheKey, entry)
+			c.decoded.store(cacheKey, entry, v)
+			return response, nil
+		}
+	}
+
+	var body io.Reader = resp.Body
+
+	err = decodeBody(body, v)
+	if err != nil {
+		if _, ok := v.(io.Writer); ok {
+			return nil, err
 		}
 	}
 
 	return response, err
 }
 
+// cacheable reports whether a response decoded into v may be stored in
+// Client.HTTPCache. Only JSON responses are cached; bodies copied to an
+// io.Writer may be large, e.g. audio, and are streamed instead.
+func cacheable(v interface{}) bool {
+	if v == nil {
+		return false
+	}
+	_, ok := v.(io.Writer)
+	return !ok
+}
+
+// responseObserver is implemented by writers passed to Do that need the
+// response headers before the body is written to them.
+type responseObserver interface {
+	observeResponse(resp *http.Response)
+}
+
+// decodeBody copies body to v if v is an io.Writer and JSON decodes it into v
+// otherwise.
+func decodeBody(body io.Reader, v interface{}) error {
+	if v == nil {
+		return nil
+	}
+
+	if w, ok := v.(io.Writer); ok {
+		_, err := io.Copy(w, body)
+		return err
+	}
+
+	err := json.NewDecoder(body).Decode(v)
+	if err == io.EOF {
+		err = nil // ignore EOF errors caused by empty response body
+	}
+
+	return err
+}
+
 func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return c.newRequest(ctx, method, urlStr, body)
+}
+
+func (c *Client) newRequest(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
 	if !strings.HasSuffix(c.BaseURL.Path, "/") {
 		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", c.BaseURL)
 	}
@@ -160,12 +321,19 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 		}
 	}
 
-	req, err := http.NewRequest(method, u.String(), buf)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.AccessToken))
+	token := c.AccessToken
+	if c.tokens != nil {
+		token, err = c.tokens.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	req.Header.Set("Authorization", "Bearer "+token.Value())
 	req.Header.Set("Accept", "application/json")
 
 	if body != nil {
@@ -202,14 +370,20 @@ func newResponse(r *http.Response) *Response {
 	return response
 }
 
+// sanitizeURL returns a copy of uri with any access token in the query and
+// any password in the user info redacted. uri is not modified.
 func sanitizeURL(uri *url.URL) *url.URL {
 	if uri == nil {
 		return nil
 	}
-	params := uri.Query()
+	c := *uri
+	if _, ok := c.User.Password(); ok {
+		c.User = url.UserPassword(c.User.Username(), redacted)
+	}
+	params := c.Query()
 	if len(params.Get("access_token")) > 0 {
-		params.Set("access_token", "REDACTED")
-		uri.RawQuery = params.Encode()
+		params.Set("access_token", redacted)
+		c.RawQuery = params.Encode()
 	}
-	return uri
+	return &c
 }
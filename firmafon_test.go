@@ -1,7 +1,12 @@
+// The tests register handlers for patterns such as ":" that the Go 1.22
+// ServeMux rejects, so keep the previous pattern syntax.
+//go:debug httpmuxgo121=1
+
 package firmafon
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -45,11 +50,24 @@ func TestNewClient(t *testing.T) {
 		t.Errorf("NewClient BaseURL is %v, want %v", actual, expected)
 	}
 
-	if actual, expected := c.AccessToken, token; actual != expected {
+	if actual, expected := c.AccessToken.Value(), token; actual != expected {
 		t.Errorf("NewClient AccessToken is %v, want %v", actual, expected)
 	}
 }
 
+func TestNewClientWithHTTPClient(t *testing.T) {
+	hc := &http.Client{}
+	c := NewClientWithHTTPClient("123", hc)
+
+	if c.client != hc {
+		t.Errorf("NewClientWithHTTPClient http.Client is %v, want %v", c.client, hc)
+	}
+
+	if c := NewClientWithHTTPClient("123", nil); c.client != http.DefaultClient {
+		t.Errorf("NewClientWithHTTPClient(nil) http.Client is %v, want http.DefaultClient", c.client)
+	}
+}
+
 func TestNewRequest(t *testing.T) {
 	c := NewClient("")
 	inURL, outURL := "users", defaultBaseURL+"users"
@@ -452,3 +470,31 @@ func TestAddOptions_Values(t *testing.T) {
 		t.Fatal("Addoptions Values did not return an error")
 	}
 }
+
+func TestClient_WithContext(t *testing.T) {
+	c := NewClient("")
+	c.Calls.Endpoint = "custom"
+
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	c2 := c.WithContext(ctx)
+
+	req, err := c2.NewRequest("GET", ".", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned unexpected error: %v", err)
+	}
+	if got := req.Context().Value(key{}); got != "value" {
+		t.Errorf("request context value = %v, want %v", got, "value")
+	}
+	if c2.Calls.client != c2 || c2.Employees.client != c2 {
+		t.Error("services of the copy use the original client")
+	}
+	if got, want := c2.Calls.Endpoint, "custom"; got != want {
+		t.Errorf("Calls.Endpoint = %q, want %q", got, want)
+	}
+
+	req, _ = c.NewRequest("GET", ".", nil)
+	if got := req.Context().Value(key{}); got != nil {
+		t.Errorf("original client request context value = %v, want nil", got)
+	}
+}
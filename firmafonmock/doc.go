@@ -0,0 +1,4 @@
+// Package firmafonmock provides mock implementations of the firmafon service
+// interfaces for use in tests. The mocks are generated by moq; run go generate
+// in the firmafon package after changing an interface.
+package firmafonmock
@@ -0,0 +1,35 @@
+package firmafonmock_test
+
+import (
+	"fmt"
+
+	firmafon "github.com/steffen25/go-firmafon"
+	"github.com/steffen25/go-firmafon/firmafonmock"
+)
+
+func countAvailable(employees firmafon.EmployeesAPI) (int, error) {
+	emps, _, err := employees.All()
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, e := range emps {
+		if !e.DoNotDisturb {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func ExampleEmployeesAPIMock() {
+	mock := &firmafonmock.EmployeesAPIMock{
+		AllFunc: func() ([]*firmafon.Employee, *firmafon.Response, error) {
+			return []*firmafon.Employee{{ID: 1}, {ID: 2, DoNotDisturb: true}}, nil, nil
+		},
+	}
+
+	n, _ := countAvailable(mock)
+	fmt.Println(n, len(mock.AllCalls()))
+	// Output: 1 1
+}
@@ -0,0 +1,1826 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package firmafonmock
+
+import (
+	"context"
+	"github.com/steffen25/go-firmafon"
+	"io"
+	"sync"
+)
+
+// Ensure, that EmployeesAPIMock does implement firmafon.EmployeesAPI.
+// If this is not the case, regenerate this file with moq.
+var _ firmafon.EmployeesAPI = &EmployeesAPIMock{}
+
+// EmployeesAPIMock is a mock implementation of firmafon.EmployeesAPI.
+//
+//	func TestSomethingThatUsesEmployeesAPI(t *testing.T) {
+//
+//		// make and configure a mocked firmafon.EmployeesAPI
+//		mockedEmployeesAPI := &EmployeesAPIMock{
+//			AllFunc: func() ([]*firmafon.Employee, *firmafon.Response, error) {
+//				panic("mock out the All method")
+//			},
+//			AuthenticatedFunc: func() (*firmafon.Employee, *firmafon.Response, error) {
+//				panic("mock out the Authenticated method")
+//			},
+//			GetByIdFunc: func(id int) (*firmafon.Employee, *firmafon.Response, error) {
+//				panic("mock out the GetById method")
+//			},
+//			SendSMSFunc: func(e *firmafon.Employee, msg string) (*firmafon.SMSResponse, *firmafon.Response, error) {
+//				panic("mock out the SendSMS method")
+//			},
+//			UpdateFunc: func(e *firmafon.Employee) (*firmafon.Employee, *firmafon.Response, error) {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedEmployeesAPI in code that requires firmafon.EmployeesAPI
+//		// and then make assertions.
+//
+//	}
+type EmployeesAPIMock struct {
+	// AllFunc mocks the All method.
+	AllFunc func() ([]*firmafon.Employee, *firmafon.Response, error)
+
+	// AuthenticatedFunc mocks the Authenticated method.
+	AuthenticatedFunc func() (*firmafon.Employee, *firmafon.Response, error)
+
+	// GetByIdFunc mocks the GetById method.
+	GetByIdFunc func(id int) (*firmafon.Employee, *firmafon.Response, error)
+
+	// SendSMSFunc mocks the SendSMS method.
+	SendSMSFunc func(e *firmafon.Employee, msg string) (*firmafon.SMSResponse, *firmafon.Response, error)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(e *firmafon.Employee) (*firmafon.Employee, *firmafon.Response, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// All holds details about calls to the All method.
+		All []struct {
+		}
+		// Authenticated holds details about calls to the Authenticated method.
+		Authenticated []struct {
+		}
+		// GetById holds details about calls to the GetById method.
+		GetById []struct {
+			// ID is the id argument value.
+			ID int
+		}
+		// SendSMS holds details about calls to the SendSMS method.
+		SendSMS []struct {
+			// E is the e argument value.
+			E *firmafon.Employee
+			// Msg is the msg argument value.
+			Msg string
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// E is the e argument value.
+			E *firmafon.Employee
+		}
+	}
+	lockAll           sync.RWMutex
+	lockAuthenticated sync.RWMutex
+	lockGetById       sync.RWMutex
+	lockSendSMS       sync.RWMutex
+	lockUpdate        sync.RWMutex
+}
+
+// All calls AllFunc.
+func (mock *EmployeesAPIMock) All() ([]*firmafon.Employee, *firmafon.Response, error) {
+	callInfo := struct {
+	}{}
+	mock.lockAll.Lock()
+	mock.calls.All = append(mock.calls.All, callInfo)
+	mock.lockAll.Unlock()
+	if mock.AllFunc == nil {
+		var (
+			employeesOut []*firmafon.Employee
+			responseOut  *firmafon.Response
+			errOut       error
+		)
+		return employeesOut, responseOut, errOut
+	}
+	return mock.AllFunc()
+}
+
+// AllCalls gets all the calls that were made to All.
+// Check the length with:
+//
+//	len(mockedEmployeesAPI.AllCalls())
+func (mock *EmployeesAPIMock) AllCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockAll.RLock()
+	calls = mock.calls.All
+	mock.lockAll.RUnlock()
+	return calls
+}
+
+// Authenticated calls AuthenticatedFunc.
+func (mock *EmployeesAPIMock) Authenticated() (*firmafon.Employee, *firmafon.Response, error) {
+	callInfo := struct {
+	}{}
+	mock.lockAuthenticated.Lock()
+	mock.calls.Authenticated = append(mock.calls.Authenticated, callInfo)
+	mock.lockAuthenticated.Unlock()
+	if mock.AuthenticatedFunc == nil {
+		var (
+			employeeOut *firmafon.Employee
+			responseOut *firmafon.Response
+			errOut      error
+		)
+		return employeeOut, responseOut, errOut
+	}
+	return mock.AuthenticatedFunc()
+}
+
+// AuthenticatedCalls gets all the calls that were made to Authenticated.
+// Check the length with:
+//
+//	len(mockedEmployeesAPI.AuthenticatedCalls())
+func (mock *EmployeesAPIMock) AuthenticatedCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockAuthenticated.RLock()
+	calls = mock.calls.Authenticated
+	mock.lockAuthenticated.RUnlock()
+	return calls
+}
+
+// GetById calls GetByIdFunc.
+func (mock *EmployeesAPIMock) GetById(id int) (*firmafon.Employee, *firmafon.Response, error) {
+	callInfo := struct {
+		ID int
+	}{
+		ID: id,
+	}
+	mock.lockGetById.Lock()
+	mock.calls.GetById = append(mock.calls.GetById, callInfo)
+	mock.lockGetById.Unlock()
+	if mock.GetByIdFunc == nil {
+		var (
+			employeeOut *firmafon.Employee
+			responseOut *firmafon.Response
+			errOut      error
+		)
+		return employeeOut, responseOut, errOut
+	}
+	return mock.GetByIdFunc(id)
+}
+
+// GetByIdCalls gets all the calls that were made to GetById.
+// Check the length with:
+//
+//	len(mockedEmployeesAPI.GetByIdCalls())
+func (mock *EmployeesAPIMock) GetByIdCalls() []struct {
+	ID int
+} {
+	var calls []struct {
+		ID int
+	}
+	mock.lockGetById.RLock()
+	calls = mock.calls.GetById
+	mock.lockGetById.RUnlock()
+	return calls
+}
+
+// SendSMS calls SendSMSFunc.
+func (mock *EmployeesAPIMock) SendSMS(e *firmafon.Employee, msg string) (*firmafon.SMSResponse, *firmafon.Response, error) {
+	callInfo := struct {
+		E   *firmafon.Employee
+		Msg string
+	}{
+		E:   e,
+		Msg: msg,
+	}
+	mock.lockSendSMS.Lock()
+	mock.calls.SendSMS = append(mock.calls.SendSMS, callInfo)
+	mock.lockSendSMS.Unlock()
+	if mock.SendSMSFunc == nil {
+		var (
+			sMSResponseOut *firmafon.SMSResponse
+			responseOut    *firmafon.Response
+			errOut         error
+		)
+		return sMSResponseOut, responseOut, errOut
+	}
+	return mock.SendSMSFunc(e, msg)
+}
+
+// SendSMSCalls gets all the calls that were made to SendSMS.
+// Check the length with:
+//
+//	len(mockedEmployeesAPI.SendSMSCalls())
+func (mock *EmployeesAPIMock) SendSMSCalls() []struct {
+	E   *firmafon.Employee
+	Msg string
+} {
+	var calls []struct {
+		E   *firmafon.Employee
+		Msg string
+	}
+	mock.lockSendSMS.RLock()
+	calls = mock.calls.SendSMS
+	mock.lockSendSMS.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *EmployeesAPIMock) Update(e *firmafon.Employee) (*firmafon.Employee, *firmafon.Response, error) {
+	callInfo := struct {
+		E *firmafon.Employee
+	}{
+		E: e,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	if mock.UpdateFunc == nil {
+		var (
+			employeeOut *firmafon.Employee
+			responseOut *firmafon.Response
+			errOut      error
+		)
+		return employeeOut, responseOut, errOut
+	}
+	return mock.UpdateFunc(e)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedEmployeesAPI.UpdateCalls())
+func (mock *EmployeesAPIMock) UpdateCalls() []struct {
+	E *firmafon.Employee
+} {
+	var calls []struct {
+		E *firmafon.Employee
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// Ensure, that CallsAPIMock does implement firmafon.CallsAPI.
+// If this is not the case, regenerate this file with moq.
+var _ firmafon.CallsAPI = &CallsAPIMock{}
+
+// CallsAPIMock is a mock implementation of firmafon.CallsAPI.
+//
+//	func TestSomethingThatUsesCallsAPI(t *testing.T) {
+//
+//		// make and configure a mocked firmafon.CallsAPI
+//		mockedCallsAPI := &CallsAPIMock{
+//			GetFunc: func(uuid string) (*firmafon.Call, *firmafon.Response, error) {
+//				panic("mock out the Get method")
+//			},
+//			GetAllFunc: func(opt *firmafon.CallsListOptions) ([]*firmafon.Call, *firmafon.Response, error) {
+//				panic("mock out the GetAll method")
+//			},
+//			RecordingFunc: func(ctx context.Context, uuid string, w io.Writer) (*firmafon.Recording, *firmafon.Response, error) {
+//				panic("mock out the Recording method")
+//			},
+//			ResumeRecordingFunc: func(ctx context.Context, uuid string, w io.Writer, offset int64) (*firmafon.Recording, *firmafon.Response, error) {
+//				panic("mock out the ResumeRecording method")
+//			},
+//		}
+//
+//		// use mockedCallsAPI in code that requires firmafon.CallsAPI
+//		// and then make assertions.
+//
+//	}
+type CallsAPIMock struct {
+	// GetFunc mocks the Get method.
+	GetFunc func(uuid string) (*firmafon.Call, *firmafon.Response, error)
+
+	// GetAllFunc mocks the GetAll method.
+	GetAllFunc func(opt *firmafon.CallsListOptions) ([]*firmafon.Call, *firmafon.Response, error)
+
+	// RecordingFunc mocks the Recording method.
+	RecordingFunc func(ctx context.Context, uuid string, w io.Writer) (*firmafon.Recording, *firmafon.Response, error)
+
+	// ResumeRecordingFunc mocks the ResumeRecording method.
+	ResumeRecordingFunc func(ctx context.Context, uuid string, w io.Writer, offset int64) (*firmafon.Recording, *firmafon.Response, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// UUID is the uuid argument value.
+			UUID string
+		}
+		// GetAll holds details about calls to the GetAll method.
+		GetAll []struct {
+			// Opt is the opt argument value.
+			Opt *firmafon.CallsListOptions
+		}
+		// Recording holds details about calls to the Recording method.
+		Recording []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UUID is the uuid argument value.
+			UUID string
+			// W is the w argument value.
+			W io.Writer
+		}
+		// ResumeRecording holds details about calls to the ResumeRecording method.
+		ResumeRecording []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UUID is the uuid argument value.
+			UUID string
+			// W is the w argument value.
+			W io.Writer
+			// Offset is the offset argument value.
+			Offset int64
+		}
+	}
+	lockGet             sync.RWMutex
+	lockGetAll          sync.RWMutex
+	lockRecording       sync.RWMutex
+	lockResumeRecording sync.RWMutex
+}
+
+// Get calls GetFunc.
+func (mock *CallsAPIMock) Get(uuid string) (*firmafon.Call, *firmafon.Response, error) {
+	callInfo := struct {
+		UUID string
+	}{
+		UUID: uuid,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	if mock.GetFunc == nil {
+		var (
+			callOut     *firmafon.Call
+			responseOut *firmafon.Response
+			errOut      error
+		)
+		return callOut, responseOut, errOut
+	}
+	return mock.GetFunc(uuid)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedCallsAPI.GetCalls())
+func (mock *CallsAPIMock) GetCalls() []struct {
+	UUID string
+} {
+	var calls []struct {
+		UUID string
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// GetAll calls GetAllFunc.
+func (mock *CallsAPIMock) GetAll(opt *firmafon.CallsListOptions) ([]*firmafon.Call, *firmafon.Response, error) {
+	callInfo := struct {
+		Opt *firmafon.CallsListOptions
+	}{
+		Opt: opt,
+	}
+	mock.lockGetAll.Lock()
+	mock.calls.GetAll = append(mock.calls.GetAll, callInfo)
+	mock.lockGetAll.Unlock()
+	if mock.GetAllFunc == nil {
+		var (
+			callsOut    []*firmafon.Call
+			responseOut *firmafon.Response
+			errOut      error
+		)
+		return callsOut, responseOut, errOut
+	}
+	return mock.GetAllFunc(opt)
+}
+
+// GetAllCalls gets all the calls that were made to GetAll.
+// Check the length with:
+//
+//	len(mockedCallsAPI.GetAllCalls())
+func (mock *CallsAPIMock) GetAllCalls() []struct {
+	Opt *firmafon.CallsListOptions
+} {
+	var calls []struct {
+		Opt *firmafon.CallsListOptions
+	}
+	mock.lockGetAll.RLock()
+	calls = mock.calls.GetAll
+	mock.lockGetAll.RUnlock()
+	return calls
+}
+
+// Recording calls RecordingFunc.
+func (mock *CallsAPIMock) Recording(ctx context.Context, uuid string, w io.Writer) (*firmafon.Recording, *firmafon.Response, error) {
+	callInfo := struct {
+		Ctx  context.Context
+		UUID string
+		W    io.Writer
+	}{
+		Ctx:  ctx,
+		UUID: uuid,
+		W:    w,
+	}
+	mock.lockRecording.Lock()
+	mock.calls.Recording = append(mock.calls.Recording, callInfo)
+	mock.lockRecording.Unlock()
+	if mock.RecordingFunc == nil {
+		var (
+			recordingOut *firmafon.Recording
+			responseOut  *firmafon.Response
+			errOut       error
+		)
+		return recordingOut, responseOut, errOut
+	}
+	return mock.RecordingFunc(ctx, uuid, w)
+}
+
+// RecordingCalls gets all the calls that were made to Recording.
+// Check the length with:
+//
+//	len(mockedCallsAPI.RecordingCalls())
+func (mock *CallsAPIMock) RecordingCalls() []struct {
+	Ctx  context.Context
+	UUID string
+	W    io.Writer
+} {
+	var calls []struct {
+		Ctx  context.Context
+		UUID string
+		W    io.Writer
+	}
+	mock.lockRecording.RLock()
+	calls = mock.calls.Recording
+	mock.lockRecording.RUnlock()
+	return calls
+}
+
+// ResumeRecording calls ResumeRecordingFunc.
+func (mock *CallsAPIMock) ResumeRecording(ctx context.Context, uuid string, w io.Writer, offset int64) (*firmafon.Recording, *firmafon.Response, error) {
+	callInfo := struct {
+		Ctx    context.Context
+		UUID   string
+		W      io.Writer
+		Offset int64
+	}{
+		Ctx:    ctx,
+		UUID:   uuid,
+		W:      w,
+		Offset: offset,
+	}
+	mock.lockResumeRecording.Lock()
+	mock.calls.ResumeRecording = append(mock.calls.ResumeRecording, callInfo)
+	mock.lockResumeRecording.Unlock()
+	if mock.ResumeRecordingFunc == nil {
+		var (
+			recordingOut *firmafon.Recording
+			responseOut  *firmafon.Response
+			errOut       error
+		)
+		return recordingOut, responseOut, errOut
+	}
+	return mock.ResumeRecordingFunc(ctx, uuid, w, offset)
+}
+
+// ResumeRecordingCalls gets all the calls that were made to ResumeRecording.
+// Check the length with:
+//
+//	len(mockedCallsAPI.ResumeRecordingCalls())
+func (mock *CallsAPIMock) ResumeRecordingCalls() []struct {
+	Ctx    context.Context
+	UUID   string
+	W      io.Writer
+	Offset int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UUID   string
+		W      io.Writer
+		Offset int64
+	}
+	mock.lockResumeRecording.RLock()
+	calls = mock.calls.ResumeRecording
+	mock.lockResumeRecording.RUnlock()
+	return calls
+}
+
+// Ensure, that CompanyAPIMock does implement firmafon.CompanyAPI.
+// If this is not the case, regenerate this file with moq.
+var _ firmafon.CompanyAPI = &CompanyAPIMock{}
+
+// CompanyAPIMock is a mock implementation of firmafon.CompanyAPI.
+//
+//	func TestSomethingThatUsesCompanyAPI(t *testing.T) {
+//
+//		// make and configure a mocked firmafon.CompanyAPI
+//		mockedCompanyAPI := &CompanyAPIMock{
+//			GetFunc: func() (*firmafon.Company, *firmafon.Response, error) {
+//				panic("mock out the Get method")
+//			},
+//			NumbersFunc: func() ([]*firmafon.CompanyNumber, *firmafon.Response, error) {
+//				panic("mock out the Numbers method")
+//			},
+//			SettingsFunc: func() (*firmafon.CompanySettings, *firmafon.Response, error) {
+//				panic("mock out the Settings method")
+//			},
+//			SubscriptionFunc: func() (*firmafon.Subscription, *firmafon.Response, error) {
+//				panic("mock out the Subscription method")
+//			},
+//		}
+//
+//		// use mockedCompanyAPI in code that requires firmafon.CompanyAPI
+//		// and then make assertions.
+//
+//	}
+type CompanyAPIMock struct {
+	// GetFunc mocks the Get method.
+	GetFunc func() (*firmafon.Company, *firmafon.Response, error)
+
+	// NumbersFunc mocks the Numbers method.
+	NumbersFunc func() ([]*firmafon.CompanyNumber, *firmafon.Response, error)
+
+	// SettingsFunc mocks the Settings method.
+	SettingsFunc func() (*firmafon.CompanySettings, *firmafon.Response, error)
+
+	// SubscriptionFunc mocks the Subscription method.
+	SubscriptionFunc func() (*firmafon.Subscription, *firmafon.Response, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Get holds details about calls to the Get method.
+		Get []struct {
+		}
+		// Numbers holds details about calls to the Numbers method.
+		Numbers []struct {
+		}
+		// Settings holds details about calls to the Settings method.
+		Settings []struct {
+		}
+		// Subscription holds details about calls to the Subscription method.
+		Subscription []struct {
+		}
+	}
+	lockGet          sync.RWMutex
+	lockNumbers      sync.RWMutex
+	lockSettings     sync.RWMutex
+	lockSubscription sync.RWMutex
+}
+
+// Get calls GetFunc.
+func (mock *CompanyAPIMock) Get() (*firmafon.Company, *firmafon.Response, error) {
+	callInfo := struct {
+	}{}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	if mock.GetFunc == nil {
+		var (
+			companyOut  *firmafon.Company
+			responseOut *firmafon.Response
+			errOut      error
+		)
+		return companyOut, responseOut, errOut
+	}
+	return mock.GetFunc()
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedCompanyAPI.GetCalls())
+func (mock *CompanyAPIMock) GetCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// Numbers calls NumbersFunc.
+func (mock *CompanyAPIMock) Numbers() ([]*firmafon.CompanyNumber, *firmafon.Response, error) {
+	callInfo := struct {
+	}{}
+	mock.lockNumbers.Lock()
+	mock.calls.Numbers = append(mock.calls.Numbers, callInfo)
+	mock.lockNumbers.Unlock()
+	if mock.NumbersFunc == nil {
+		var (
+			companyNumbersOut []*firmafon.CompanyNumber
+			responseOut       *firmafon.Response
+			errOut            error
+		)
+		return companyNumbersOut, responseOut, errOut
+	}
+	return mock.NumbersFunc()
+}
+
+// NumbersCalls gets all the calls that were made to Numbers.
+// Check the length with:
+//
+//	len(mockedCompanyAPI.NumbersCalls())
+func (mock *CompanyAPIMock) NumbersCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockNumbers.RLock()
+	calls = mock.calls.Numbers
+	mock.lockNumbers.RUnlock()
+	return calls
+}
+
+// Settings calls SettingsFunc.
+func (mock *CompanyAPIMock) Settings() (*firmafon.CompanySettings, *firmafon.Response, error) {
+	callInfo := struct {
+	}{}
+	mock.lockSettings.Lock()
+	mock.calls.Settings = append(mock.calls.Settings, callInfo)
+	mock.lockSettings.Unlock()
+	if mock.SettingsFunc == nil {
+		var (
+			companySettingsOut *firmafon.CompanySettings
+			responseOut        *firmafon.Response
+			errOut             error
+		)
+		return companySettingsOut, responseOut, errOut
+	}
+	return mock.SettingsFunc()
+}
+
+// SettingsCalls gets all the calls that were made to Settings.
+// Check the length with:
+//
+//	len(mockedCompanyAPI.SettingsCalls())
+func (mock *CompanyAPIMock) SettingsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockSettings.RLock()
+	calls = mock.calls.Settings
+	mock.lockSettings.RUnlock()
+	return calls
+}
+
+// Subscription calls SubscriptionFunc.
+func (mock *CompanyAPIMock) Subscription() (*firmafon.Subscription, *firmafon.Response, error) {
+	callInfo := struct {
+	}{}
+	mock.lockSubscription.Lock()
+	mock.calls.Subscription = append(mock.calls.Subscription, callInfo)
+	mock.lockSubscription.Unlock()
+	if mock.SubscriptionFunc == nil {
+		var (
+			subscriptionOut *firmafon.Subscription
+			responseOut     *firmafon.Response
+			errOut          error
+		)
+		return subscriptionOut, responseOut, errOut
+	}
+	return mock.SubscriptionFunc()
+}
+
+// SubscriptionCalls gets all the calls that were made to Subscription.
+// Check the length with:
+//
+//	len(mockedCompanyAPI.SubscriptionCalls())
+func (mock *CompanyAPIMock) SubscriptionCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockSubscription.RLock()
+	calls = mock.calls.Subscription
+	mock.lockSubscription.RUnlock()
+	return calls
+}
+
+// Ensure, that NumbersAPIMock does implement firmafon.NumbersAPI.
+// If this is not the case, regenerate this file with moq.
+var _ firmafon.NumbersAPI = &NumbersAPIMock{}
+
+// NumbersAPIMock is a mock implementation of firmafon.NumbersAPI.
+//
+//	func TestSomethingThatUsesNumbersAPI(t *testing.T) {
+//
+//		// make and configure a mocked firmafon.NumbersAPI
+//		mockedNumbersAPI := &NumbersAPIMock{
+//			GetFunc: func(id int) (*firmafon.CompanyNumber, *firmafon.Response, error) {
+//				panic("mock out the Get method")
+//			},
+//			ListFunc: func() ([]*firmafon.CompanyNumber, *firmafon.Response, error) {
+//				panic("mock out the List method")
+//			},
+//			SetRoutingFunc: func(id int, endpoint firmafon.Endpoint) (*firmafon.CompanyNumber, *firmafon.Response, error) {
+//				panic("mock out the SetRouting method")
+//			},
+//		}
+//
+//		// use mockedNumbersAPI in code that requires firmafon.NumbersAPI
+//		// and then make assertions.
+//
+//	}
+type NumbersAPIMock struct {
+	// GetFunc mocks the Get method.
+	GetFunc func(id int) (*firmafon.CompanyNumber, *firmafon.Response, error)
+
+	// ListFunc mocks the List method.
+	ListFunc func() ([]*firmafon.CompanyNumber, *firmafon.Response, error)
+
+	// SetRoutingFunc mocks the SetRouting method.
+	SetRoutingFunc func(id int, endpoint firmafon.Endpoint) (*firmafon.CompanyNumber, *firmafon.Response, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// ID is the id argument value.
+			ID int
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+		}
+		// SetRouting holds details about calls to the SetRouting method.
+		SetRouting []struct {
+			// ID is the id argument value.
+			ID int
+			// Endpoint is the endpoint argument value.
+			Endpoint firmafon.Endpoint
+		}
+	}
+	lockGet        sync.RWMutex
+	lockList       sync.RWMutex
+	lockSetRouting sync.RWMutex
+}
+
+// Get calls GetFunc.
+func (mock *NumbersAPIMock) Get(id int) (*firmafon.CompanyNumber, *firmafon.Response, error) {
+	callInfo := struct {
+		ID int
+	}{
+		ID: id,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	if mock.GetFunc == nil {
+		var (
+			companyNumberOut *firmafon.CompanyNumber
+			responseOut      *firmafon.Response
+			errOut           error
+		)
+		return companyNumberOut, responseOut, errOut
+	}
+	return mock.GetFunc(id)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedNumbersAPI.GetCalls())
+func (mock *NumbersAPIMock) GetCalls() []struct {
+	ID int
+} {
+	var calls []struct {
+		ID int
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *NumbersAPIMock) List() ([]*firmafon.CompanyNumber, *firmafon.Response, error) {
+	callInfo := struct {
+	}{}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	if mock.ListFunc == nil {
+		var (
+			companyNumbersOut []*firmafon.CompanyNumber
+			responseOut       *firmafon.Response
+			errOut            error
+		)
+		return companyNumbersOut, responseOut, errOut
+	}
+	return mock.ListFunc()
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedNumbersAPI.ListCalls())
+func (mock *NumbersAPIMock) ListCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// SetRouting calls SetRoutingFunc.
+func (mock *NumbersAPIMock) SetRouting(id int, endpoint firmafon.Endpoint) (*firmafon.CompanyNumber, *firmafon.Response, error) {
+	callInfo := struct {
+		ID       int
+		Endpoint firmafon.Endpoint
+	}{
+		ID:       id,
+		Endpoint: endpoint,
+	}
+	mock.lockSetRouting.Lock()
+	mock.calls.SetRouting = append(mock.calls.SetRouting, callInfo)
+	mock.lockSetRouting.Unlock()
+	if mock.SetRoutingFunc == nil {
+		var (
+			companyNumberOut *firmafon.CompanyNumber
+			responseOut      *firmafon.Response
+			errOut           error
+		)
+		return companyNumberOut, responseOut, errOut
+	}
+	return mock.SetRoutingFunc(id, endpoint)
+}
+
+// SetRoutingCalls gets all the calls that were made to SetRouting.
+// Check the length with:
+//
+//	len(mockedNumbersAPI.SetRoutingCalls())
+func (mock *NumbersAPIMock) SetRoutingCalls() []struct {
+	ID       int
+	Endpoint firmafon.Endpoint
+} {
+	var calls []struct {
+		ID       int
+		Endpoint firmafon.Endpoint
+	}
+	mock.lockSetRouting.RLock()
+	calls = mock.calls.SetRouting
+	mock.lockSetRouting.RUnlock()
+	return calls
+}
+
+// Ensure, that VoicemailsAPIMock does implement firmafon.VoicemailsAPI.
+// If this is not the case, regenerate this file with moq.
+var _ firmafon.VoicemailsAPI = &VoicemailsAPIMock{}
+
+// VoicemailsAPIMock is a mock implementation of firmafon.VoicemailsAPI.
+//
+//	func TestSomethingThatUsesVoicemailsAPI(t *testing.T) {
+//
+//		// make and configure a mocked firmafon.VoicemailsAPI
+//		mockedVoicemailsAPI := &VoicemailsAPIMock{
+//			AudioFunc: func(id int, w io.Writer) (*firmafon.Response, error) {
+//				panic("mock out the Audio method")
+//			},
+//			DeleteFunc: func(id int) (*firmafon.Response, error) {
+//				panic("mock out the Delete method")
+//			},
+//			GetFunc: func(id int) (*firmafon.Voicemail, *firmafon.Response, error) {
+//				panic("mock out the Get method")
+//			},
+//			ListFunc: func(opt *firmafon.VoicemailsListOptions) ([]*firmafon.Voicemail, *firmafon.Response, error) {
+//				panic("mock out the List method")
+//			},
+//			MarkReadFunc: func(id int) (*firmafon.Voicemail, *firmafon.Response, error) {
+//				panic("mock out the MarkRead method")
+//			},
+//		}
+//
+//		// use mockedVoicemailsAPI in code that requires firmafon.VoicemailsAPI
+//		// and then make assertions.
+//
+//	}
+type VoicemailsAPIMock struct {
+	// AudioFunc mocks the Audio method.
+	AudioFunc func(id int, w io.Writer) (*firmafon.Response, error)
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(id int) (*firmafon.Response, error)
+
+	// GetFunc mocks the Get method.
+	GetFunc func(id int) (*firmafon.Voicemail, *firmafon.Response, error)
+
+	// ListFunc mocks the List method.
+	ListFunc func(opt *firmafon.VoicemailsListOptions) ([]*firmafon.Voicemail, *firmafon.Response, error)
+
+	// MarkReadFunc mocks the MarkRead method.
+	MarkReadFunc func(id int) (*firmafon.Voicemail, *firmafon.Response, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Audio holds details about calls to the Audio method.
+		Audio []struct {
+			// ID is the id argument value.
+			ID int
+			// W is the w argument value.
+			W io.Writer
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// ID is the id argument value.
+			ID int
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// ID is the id argument value.
+			ID int
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Opt is the opt argument value.
+			Opt *firmafon.VoicemailsListOptions
+		}
+		// MarkRead holds details about calls to the MarkRead method.
+		MarkRead []struct {
+			// ID is the id argument value.
+			ID int
+		}
+	}
+	lockAudio    sync.RWMutex
+	lockDelete   sync.RWMutex
+	lockGet      sync.RWMutex
+	lockList     sync.RWMutex
+	lockMarkRead sync.RWMutex
+}
+
+// Audio calls AudioFunc.
+func (mock *VoicemailsAPIMock) Audio(id int, w io.Writer) (*firmafon.Response, error) {
+	callInfo := struct {
+		ID int
+		W  io.Writer
+	}{
+		ID: id,
+		W:  w,
+	}
+	mock.lockAudio.Lock()
+	mock.calls.Audio = append(mock.calls.Audio, callInfo)
+	mock.lockAudio.Unlock()
+	if mock.AudioFunc == nil {
+		var (
+			responseOut *firmafon.Response
+			errOut      error
+		)
+		return responseOut, errOut
+	}
+	return mock.AudioFunc(id, w)
+}
+
+// AudioCalls gets all the calls that were made to Audio.
+// Check the length with:
+//
+//	len(mockedVoicemailsAPI.AudioCalls())
+func (mock *VoicemailsAPIMock) AudioCalls() []struct {
+	ID int
+	W  io.Writer
+} {
+	var calls []struct {
+		ID int
+		W  io.Writer
+	}
+	mock.lockAudio.RLock()
+	calls = mock.calls.Audio
+	mock.lockAudio.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *VoicemailsAPIMock) Delete(id int) (*firmafon.Response, error) {
+	callInfo := struct {
+		ID int
+	}{
+		ID: id,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	if mock.DeleteFunc == nil {
+		var (
+			responseOut *firmafon.Response
+			errOut      error
+		)
+		return responseOut, errOut
+	}
+	return mock.DeleteFunc(id)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedVoicemailsAPI.DeleteCalls())
+func (mock *VoicemailsAPIMock) DeleteCalls() []struct {
+	ID int
+} {
+	var calls []struct {
+		ID int
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// Get calls GetFunc.
+func (mock *VoicemailsAPIMock) Get(id int) (*firmafon.Voicemail, *firmafon.Response, error) {
+	callInfo := struct {
+		ID int
+	}{
+		ID: id,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	if mock.GetFunc == nil {
+		var (
+			voicemailOut *firmafon.Voicemail
+			responseOut  *firmafon.Response
+			errOut       error
+		)
+		return voicemailOut, responseOut, errOut
+	}
+	return mock.GetFunc(id)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedVoicemailsAPI.GetCalls())
+func (mock *VoicemailsAPIMock) GetCalls() []struct {
+	ID int
+} {
+	var calls []struct {
+		ID int
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *VoicemailsAPIMock) List(opt *firmafon.VoicemailsListOptions) ([]*firmafon.Voicemail, *firmafon.Response, error) {
+	callInfo := struct {
+		Opt *firmafon.VoicemailsListOptions
+	}{
+		Opt: opt,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	if mock.ListFunc == nil {
+		var (
+			voicemailsOut []*firmafon.Voicemail
+			responseOut   *firmafon.Response
+			errOut        error
+		)
+		return voicemailsOut, responseOut, errOut
+	}
+	return mock.ListFunc(opt)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedVoicemailsAPI.ListCalls())
+func (mock *VoicemailsAPIMock) ListCalls() []struct {
+	Opt *firmafon.VoicemailsListOptions
+} {
+	var calls []struct {
+		Opt *firmafon.VoicemailsListOptions
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// MarkRead calls MarkReadFunc.
+func (mock *VoicemailsAPIMock) MarkRead(id int) (*firmafon.Voicemail, *firmafon.Response, error) {
+	callInfo := struct {
+		ID int
+	}{
+		ID: id,
+	}
+	mock.lockMarkRead.Lock()
+	mock.calls.MarkRead = append(mock.calls.MarkRead, callInfo)
+	mock.lockMarkRead.Unlock()
+	if mock.MarkReadFunc == nil {
+		var (
+			voicemailOut *firmafon.Voicemail
+			responseOut  *firmafon.Response
+			errOut       error
+		)
+		return voicemailOut, responseOut, errOut
+	}
+	return mock.MarkReadFunc(id)
+}
+
+// MarkReadCalls gets all the calls that were made to MarkRead.
+// Check the length with:
+//
+//	len(mockedVoicemailsAPI.MarkReadCalls())
+func (mock *VoicemailsAPIMock) MarkReadCalls() []struct {
+	ID int
+} {
+	var calls []struct {
+		ID int
+	}
+	mock.lockMarkRead.RLock()
+	calls = mock.calls.MarkRead
+	mock.lockMarkRead.RUnlock()
+	return calls
+}
+
+// Ensure, that ContactsAPIMock does implement firmafon.ContactsAPI.
+// If this is not the case, regenerate this file with moq.
+var _ firmafon.ContactsAPI = &ContactsAPIMock{}
+
+// ContactsAPIMock is a mock implementation of firmafon.ContactsAPI.
+//
+//	func TestSomethingThatUsesContactsAPI(t *testing.T) {
+//
+//		// make and configure a mocked firmafon.ContactsAPI
+//		mockedContactsAPI := &ContactsAPIMock{
+//			CreateFunc: func(c *firmafon.Contact) (*firmafon.Contact, *firmafon.Response, error) {
+//				panic("mock out the Create method")
+//			},
+//			DeleteFunc: func(id int) (*firmafon.Response, error) {
+//				panic("mock out the Delete method")
+//			},
+//			GetFunc: func(id int) (*firmafon.Contact, *firmafon.Response, error) {
+//				panic("mock out the Get method")
+//			},
+//			ImportFunc: func(contacts []*firmafon.Contact) ([]*firmafon.Contact, *firmafon.Response, error) {
+//				panic("mock out the Import method")
+//			},
+//			ListFunc: func(opt *firmafon.ContactsListOptions) ([]*firmafon.Contact, *firmafon.Response, error) {
+//				panic("mock out the List method")
+//			},
+//			SearchByNumberFunc: func(number string) ([]*firmafon.Contact, *firmafon.Response, error) {
+//				panic("mock out the SearchByNumber method")
+//			},
+//			UpdateFunc: func(c *firmafon.Contact) (*firmafon.Contact, *firmafon.Response, error) {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedContactsAPI in code that requires firmafon.ContactsAPI
+//		// and then make assertions.
+//
+//	}
+type ContactsAPIMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(c *firmafon.Contact) (*firmafon.Contact, *firmafon.Response, error)
+
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(id int) (*firmafon.Response, error)
+
+	// GetFunc mocks the Get method.
+	GetFunc func(id int) (*firmafon.Contact, *firmafon.Response, error)
+
+	// ImportFunc mocks the Import method.
+	ImportFunc func(contacts []*firmafon.Contact) ([]*firmafon.Contact, *firmafon.Response, error)
+
+	// ListFunc mocks the List method.
+	ListFunc func(opt *firmafon.ContactsListOptions) ([]*firmafon.Contact, *firmafon.Response, error)
+
+	// SearchByNumberFunc mocks the SearchByNumber method.
+	SearchByNumberFunc func(number string) ([]*firmafon.Contact, *firmafon.Response, error)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(c *firmafon.Contact) (*firmafon.Contact, *firmafon.Response, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// C is the c argument value.
+			C *firmafon.Contact
+		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// ID is the id argument value.
+			ID int
+		}
+		// Get holds details about calls to the Get method.
+		Get []struct {
+			// ID is the id argument value.
+			ID int
+		}
+		// Import holds details about calls to the Import method.
+		Import []struct {
+			// Contacts is the contacts argument value.
+			Contacts []*firmafon.Contact
+		}
+		// List holds details about calls to the List method.
+		List []struct {
+			// Opt is the opt argument value.
+			Opt *firmafon.ContactsListOptions
+		}
+		// SearchByNumber holds details about calls to the SearchByNumber method.
+		SearchByNumber []struct {
+			// Number is the number argument value.
+			Number string
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// C is the c argument value.
+			C *firmafon.Contact
+		}
+	}
+	lockCreate         sync.RWMutex
+	lockDelete         sync.RWMutex
+	lockGet            sync.RWMutex
+	lockImport         sync.RWMutex
+	lockList           sync.RWMutex
+	lockSearchByNumber sync.RWMutex
+	lockUpdate         sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *ContactsAPIMock) Create(c *firmafon.Contact) (*firmafon.Contact, *firmafon.Response, error) {
+	callInfo := struct {
+		C *firmafon.Contact
+	}{
+		C: c,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	if mock.CreateFunc == nil {
+		var (
+			contactOut  *firmafon.Contact
+			responseOut *firmafon.Response
+			errOut      error
+		)
+		return contactOut, responseOut, errOut
+	}
+	return mock.CreateFunc(c)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedContactsAPI.CreateCalls())
+func (mock *ContactsAPIMock) CreateCalls() []struct {
+	C *firmafon.Contact
+} {
+	var calls []struct {
+		C *firmafon.Contact
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
+
+// Delete calls DeleteFunc.
+func (mock *ContactsAPIMock) Delete(id int) (*firmafon.Response, error) {
+	callInfo := struct {
+		ID int
+	}{
+		ID: id,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	if mock.DeleteFunc == nil {
+		var (
+			responseOut *firmafon.Response
+			errOut      error
+		)
+		return responseOut, errOut
+	}
+	return mock.DeleteFunc(id)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedContactsAPI.DeleteCalls())
+func (mock *ContactsAPIMock) DeleteCalls() []struct {
+	ID int
+} {
+	var calls []struct {
+		ID int
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// Get calls GetFunc.
+func (mock *ContactsAPIMock) Get(id int) (*firmafon.Contact, *firmafon.Response, error) {
+	callInfo := struct {
+		ID int
+	}{
+		ID: id,
+	}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	if mock.GetFunc == nil {
+		var (
+			contactOut  *firmafon.Contact
+			responseOut *firmafon.Response
+			errOut      error
+		)
+		return contactOut, responseOut, errOut
+	}
+	return mock.GetFunc(id)
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedContactsAPI.GetCalls())
+func (mock *ContactsAPIMock) GetCalls() []struct {
+	ID int
+} {
+	var calls []struct {
+		ID int
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// Import calls ImportFunc.
+func (mock *ContactsAPIMock) Import(contacts []*firmafon.Contact) ([]*firmafon.Contact, *firmafon.Response, error) {
+	callInfo := struct {
+		Contacts []*firmafon.Contact
+	}{
+		Contacts: contacts,
+	}
+	mock.lockImport.Lock()
+	mock.calls.Import = append(mock.calls.Import, callInfo)
+	mock.lockImport.Unlock()
+	if mock.ImportFunc == nil {
+		var (
+			contactsOut []*firmafon.Contact
+			responseOut *firmafon.Response
+			errOut      error
+		)
+		return contactsOut, responseOut, errOut
+	}
+	return mock.ImportFunc(contacts)
+}
+
+// ImportCalls gets all the calls that were made to Import.
+// Check the length with:
+//
+//	len(mockedContactsAPI.ImportCalls())
+func (mock *ContactsAPIMock) ImportCalls() []struct {
+	Contacts []*firmafon.Contact
+} {
+	var calls []struct {
+		Contacts []*firmafon.Contact
+	}
+	mock.lockImport.RLock()
+	calls = mock.calls.Import
+	mock.lockImport.RUnlock()
+	return calls
+}
+
+// List calls ListFunc.
+func (mock *ContactsAPIMock) List(opt *firmafon.ContactsListOptions) ([]*firmafon.Contact, *firmafon.Response, error) {
+	callInfo := struct {
+		Opt *firmafon.ContactsListOptions
+	}{
+		Opt: opt,
+	}
+	mock.lockList.Lock()
+	mock.calls.List = append(mock.calls.List, callInfo)
+	mock.lockList.Unlock()
+	if mock.ListFunc == nil {
+		var (
+			contactsOut []*firmafon.Contact
+			responseOut *firmafon.Response
+			errOut      error
+		)
+		return contactsOut, responseOut, errOut
+	}
+	return mock.ListFunc(opt)
+}
+
+// ListCalls gets all the calls that were made to List.
+// Check the length with:
+//
+//	len(mockedContactsAPI.ListCalls())
+func (mock *ContactsAPIMock) ListCalls() []struct {
+	Opt *firmafon.ContactsListOptions
+} {
+	var calls []struct {
+		Opt *firmafon.ContactsListOptions
+	}
+	mock.lockList.RLock()
+	calls = mock.calls.List
+	mock.lockList.RUnlock()
+	return calls
+}
+
+// SearchByNumber calls SearchByNumberFunc.
+func (mock *ContactsAPIMock) SearchByNumber(number string) ([]*firmafon.Contact, *firmafon.Response, error) {
+	callInfo := struct {
+		Number string
+	}{
+		Number: number,
+	}
+	mock.lockSearchByNumber.Lock()
+	mock.calls.SearchByNumber = append(mock.calls.SearchByNumber, callInfo)
+	mock.lockSearchByNumber.Unlock()
+	if mock.SearchByNumberFunc == nil {
+		var (
+			contactsOut []*firmafon.Contact
+			responseOut *firmafon.Response
+			errOut      error
+		)
+		return contactsOut, responseOut, errOut
+	}
+	return mock.SearchByNumberFunc(number)
+}
+
+// SearchByNumberCalls gets all the calls that were made to SearchByNumber.
+// Check the length with:
+//
+//	len(mockedContactsAPI.SearchByNumberCalls())
+func (mock *ContactsAPIMock) SearchByNumberCalls() []struct {
+	Number string
+} {
+	var calls []struct {
+		Number string
+	}
+	mock.lockSearchByNumber.RLock()
+	calls = mock.calls.SearchByNumber
+	mock.lockSearchByNumber.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *ContactsAPIMock) Update(c *firmafon.Contact) (*firmafon.Contact, *firmafon.Response, error) {
+	callInfo := struct {
+		C *firmafon.Contact
+	}{
+		C: c,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	if mock.UpdateFunc == nil {
+		var (
+			contactOut  *firmafon.Contact
+			responseOut *firmafon.Response
+			errOut      error
+		)
+		return contactOut, responseOut, errOut
+	}
+	return mock.UpdateFunc(c)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedContactsAPI.UpdateCalls())
+func (mock *ContactsAPIMock) UpdateCalls() []struct {
+	C *firmafon.Contact
+} {
+	var calls []struct {
+		C *firmafon.Contact
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
+
+// Ensure, that ReceptionAPIMock does implement firmafon.ReceptionAPI.
+// If this is not the case, regenerate this file with moq.
+var _ firmafon.ReceptionAPI = &ReceptionAPIMock{}
+
+// ReceptionAPIMock is a mock implementation of firmafon.ReceptionAPI.
+//
+//	func TestSomethingThatUsesReceptionAPI(t *testing.T) {
+//
+//		// make and configure a mocked firmafon.ReceptionAPI
+//		mockedReceptionAPI := &ReceptionAPIMock{
+//			QueueFunc: func() ([]*firmafon.QueuedCall, *firmafon.Response, error) {
+//				panic("mock out the Queue method")
+//			},
+//			SignInFunc: func(employeeID int) (*firmafon.Response, error) {
+//				panic("mock out the SignIn method")
+//			},
+//			SignOutFunc: func(employeeID int) (*firmafon.Response, error) {
+//				panic("mock out the SignOut method")
+//			},
+//			SignedInFunc: func() ([]*firmafon.Employee, *firmafon.Response, error) {
+//				panic("mock out the SignedIn method")
+//			},
+//		}
+//
+//		// use mockedReceptionAPI in code that requires firmafon.ReceptionAPI
+//		// and then make assertions.
+//
+//	}
+type ReceptionAPIMock struct {
+	// QueueFunc mocks the Queue method.
+	QueueFunc func() ([]*firmafon.QueuedCall, *firmafon.Response, error)
+
+	// SignInFunc mocks the SignIn method.
+	SignInFunc func(employeeID int) (*firmafon.Response, error)
+
+	// SignOutFunc mocks the SignOut method.
+	SignOutFunc func(employeeID int) (*firmafon.Response, error)
+
+	// SignedInFunc mocks the SignedIn method.
+	SignedInFunc func() ([]*firmafon.Employee, *firmafon.Response, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Queue holds details about calls to the Queue method.
+		Queue []struct {
+		}
+		// SignIn holds details about calls to the SignIn method.
+		SignIn []struct {
+			// EmployeeID is the employeeID argument value.
+			EmployeeID int
+		}
+		// SignOut holds details about calls to the SignOut method.
+		SignOut []struct {
+			// EmployeeID is the employeeID argument value.
+			EmployeeID int
+		}
+		// SignedIn holds details about calls to the SignedIn method.
+		SignedIn []struct {
+		}
+	}
+	lockQueue    sync.RWMutex
+	lockSignIn   sync.RWMutex
+	lockSignOut  sync.RWMutex
+	lockSignedIn sync.RWMutex
+}
+
+// Queue calls QueueFunc.
+func (mock *ReceptionAPIMock) Queue() ([]*firmafon.QueuedCall, *firmafon.Response, error) {
+	callInfo := struct {
+	}{}
+	mock.lockQueue.Lock()
+	mock.calls.Queue = append(mock.calls.Queue, callInfo)
+	mock.lockQueue.Unlock()
+	if mock.QueueFunc == nil {
+		var (
+			queuedCallsOut []*firmafon.QueuedCall
+			responseOut    *firmafon.Response
+			errOut         error
+		)
+		return queuedCallsOut, responseOut, errOut
+	}
+	return mock.QueueFunc()
+}
+
+// QueueCalls gets all the calls that were made to Queue.
+// Check the length with:
+//
+//	len(mockedReceptionAPI.QueueCalls())
+func (mock *ReceptionAPIMock) QueueCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockQueue.RLock()
+	calls = mock.calls.Queue
+	mock.lockQueue.RUnlock()
+	return calls
+}
+
+// SignIn calls SignInFunc.
+func (mock *ReceptionAPIMock) SignIn(employeeID int) (*firmafon.Response, error) {
+	callInfo := struct {
+		EmployeeID int
+	}{
+		EmployeeID: employeeID,
+	}
+	mock.lockSignIn.Lock()
+	mock.calls.SignIn = append(mock.calls.SignIn, callInfo)
+	mock.lockSignIn.Unlock()
+	if mock.SignInFunc == nil {
+		var (
+			responseOut *firmafon.Response
+			errOut      error
+		)
+		return responseOut, errOut
+	}
+	return mock.SignInFunc(employeeID)
+}
+
+// SignInCalls gets all the calls that were made to SignIn.
+// Check the length with:
+//
+//	len(mockedReceptionAPI.SignInCalls())
+func (mock *ReceptionAPIMock) SignInCalls() []struct {
+	EmployeeID int
+} {
+	var calls []struct {
+		EmployeeID int
+	}
+	mock.lockSignIn.RLock()
+	calls = mock.calls.SignIn
+	mock.lockSignIn.RUnlock()
+	return calls
+}
+
+// SignOut calls SignOutFunc.
+func (mock *ReceptionAPIMock) SignOut(employeeID int) (*firmafon.Response, error) {
+	callInfo := struct {
+		EmployeeID int
+	}{
+		EmployeeID: employeeID,
+	}
+	mock.lockSignOut.Lock()
+	mock.calls.SignOut = append(mock.calls.SignOut, callInfo)
+	mock.lockSignOut.Unlock()
+	if mock.SignOutFunc == nil {
+		var (
+			responseOut *firmafon.Response
+			errOut      error
+		)
+		return responseOut, errOut
+	}
+	return mock.SignOutFunc(employeeID)
+}
+
+// SignOutCalls gets all the calls that were made to SignOut.
+// Check the length with:
+//
+//	len(mockedReceptionAPI.SignOutCalls())
+func (mock *ReceptionAPIMock) SignOutCalls() []struct {
+	EmployeeID int
+} {
+	var calls []struct {
+		EmployeeID int
+	}
+	mock.lockSignOut.RLock()
+	calls = mock.calls.SignOut
+	mock.lockSignOut.RUnlock()
+	return calls
+}
+
+// SignedIn calls SignedInFunc.
+func (mock *ReceptionAPIMock) SignedIn() ([]*firmafon.Employee, *firmafon.Response, error) {
+	callInfo := struct {
+	}{}
+	mock.lockSignedIn.Lock()
+	mock.calls.SignedIn = append(mock.calls.SignedIn, callInfo)
+	mock.lockSignedIn.Unlock()
+	if mock.SignedInFunc == nil {
+		var (
+			employeesOut []*firmafon.Employee
+			responseOut  *firmafon.Response
+			errOut       error
+		)
+		return employeesOut, responseOut, errOut
+	}
+	return mock.SignedInFunc()
+}
+
+// SignedInCalls gets all the calls that were made to SignedIn.
+// Check the length with:
+//
+//	len(mockedReceptionAPI.SignedInCalls())
+func (mock *ReceptionAPIMock) SignedInCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockSignedIn.RLock()
+	calls = mock.calls.SignedIn
+	mock.lockSignedIn.RUnlock()
+	return calls
+}
+
+// Ensure, that OpeningHoursAPIMock does implement firmafon.OpeningHoursAPI.
+// If this is not the case, regenerate this file with moq.
+var _ firmafon.OpeningHoursAPI = &OpeningHoursAPIMock{}
+
+// OpeningHoursAPIMock is a mock implementation of firmafon.OpeningHoursAPI.
+//
+//	func TestSomethingThatUsesOpeningHoursAPI(t *testing.T) {
+//
+//		// make and configure a mocked firmafon.OpeningHoursAPI
+//		mockedOpeningHoursAPI := &OpeningHoursAPIMock{
+//			GetFunc: func() (*firmafon.OpeningHours, *firmafon.Response, error) {
+//				panic("mock out the Get method")
+//			},
+//			UpdateFunc: func(h *firmafon.OpeningHours) (*firmafon.OpeningHours, *firmafon.Response, error) {
+//				panic("mock out the Update method")
+//			},
+//		}
+//
+//		// use mockedOpeningHoursAPI in code that requires firmafon.OpeningHoursAPI
+//		// and then make assertions.
+//
+//	}
+type OpeningHoursAPIMock struct {
+	// GetFunc mocks the Get method.
+	GetFunc func() (*firmafon.OpeningHours, *firmafon.Response, error)
+
+	// UpdateFunc mocks the Update method.
+	UpdateFunc func(h *firmafon.OpeningHours) (*firmafon.OpeningHours, *firmafon.Response, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Get holds details about calls to the Get method.
+		Get []struct {
+		}
+		// Update holds details about calls to the Update method.
+		Update []struct {
+			// H is the h argument value.
+			H *firmafon.OpeningHours
+		}
+	}
+	lockGet    sync.RWMutex
+	lockUpdate sync.RWMutex
+}
+
+// Get calls GetFunc.
+func (mock *OpeningHoursAPIMock) Get() (*firmafon.OpeningHours, *firmafon.Response, error) {
+	callInfo := struct {
+	}{}
+	mock.lockGet.Lock()
+	mock.calls.Get = append(mock.calls.Get, callInfo)
+	mock.lockGet.Unlock()
+	if mock.GetFunc == nil {
+		var (
+			openingHoursOut *firmafon.OpeningHours
+			responseOut     *firmafon.Response
+			errOut          error
+		)
+		return openingHoursOut, responseOut, errOut
+	}
+	return mock.GetFunc()
+}
+
+// GetCalls gets all the calls that were made to Get.
+// Check the length with:
+//
+//	len(mockedOpeningHoursAPI.GetCalls())
+func (mock *OpeningHoursAPIMock) GetCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGet.RLock()
+	calls = mock.calls.Get
+	mock.lockGet.RUnlock()
+	return calls
+}
+
+// Update calls UpdateFunc.
+func (mock *OpeningHoursAPIMock) Update(h *firmafon.OpeningHours) (*firmafon.OpeningHours, *firmafon.Response, error) {
+	callInfo := struct {
+		H *firmafon.OpeningHours
+	}{
+		H: h,
+	}
+	mock.lockUpdate.Lock()
+	mock.calls.Update = append(mock.calls.Update, callInfo)
+	mock.lockUpdate.Unlock()
+	if mock.UpdateFunc == nil {
+		var (
+			openingHoursOut *firmafon.OpeningHours
+			responseOut     *firmafon.Response
+			errOut          error
+		)
+		return openingHoursOut, responseOut, errOut
+	}
+	return mock.UpdateFunc(h)
+}
+
+// UpdateCalls gets all the calls that were made to Update.
+// Check the length with:
+//
+//	len(mockedOpeningHoursAPI.UpdateCalls())
+func (mock *OpeningHoursAPIMock) UpdateCalls() []struct {
+	H *firmafon.OpeningHours
+} {
+	var calls []struct {
+		H *firmafon.OpeningHours
+	}
+	mock.lockUpdate.RLock()
+	calls = mock.calls.Update
+	mock.lockUpdate.RUnlock()
+	return calls
+}
@@ -0,0 +1,351 @@
+package firmafontest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode selects whether a Recorder records or replays interactions.
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette and never touches the
+	// network.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends requests to the API and writes the interactions to the
+	// cassette when the Recorder is stopped.
+	ModeRecord
+)
+
+// ErrInteractionNotFound is returned in replay mode when the cassette holds no
+// unused interaction matching a request.
+var ErrInteractionNotFound = errors.New("firmafontest: no recorded interaction matches the request")
+
+// Cassette is a recorded sequence of interactions with the API.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of a request that is recorded and matched on
+// replay. The query has access tokens removed and phone numbers redacted.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded response. Phone numbers in JSON bodies are
+// replaced with fake numbers that are consistent within the cassette.
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+
+	// Encoding is "base64" for bodies that are not valid UTF-8.
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records interactions with the API to
+// a cassette file or replays them from it.
+//
+//	rec, err := firmafontest.NewRecorder("testdata/employees.json", firmafontest.ModeReplay)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Stop()
+//
+//	client := firmafon.NewClientWithHTTPClient(os.Getenv("FIRMAFON_TOKEN"), rec.HTTPClient())
+type Recorder struct {
+	// Transport sends requests in record mode. If nil, http.DefaultTransport
+	// is used.
+	Transport http.RoundTripper
+
+	mode Mode
+	path string
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+	numbers  map[string]string
+}
+
+// NewRecorder returns a Recorder for the cassette at path. In replay mode the
+// cassette is loaded from path.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		mode:     mode,
+		path:     path,
+		cassette: &Cassette{},
+		numbers:  make(map[string]string),
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, r.cassette); err != nil {
+			return nil, fmt.Errorf("firmafontest: reading cassette %s: %v", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// HTTPClient returns an http.Client using r as its transport.
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Cassette returns the interactions recorded or loaded so far.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := &Cassette{Interactions: make([]*Interaction, len(r.cassette.Interactions))}
+	copy(c.Interactions, r.cassette.Interactions)
+	return c
+}
+
+// Stop writes the cassette to disk in record mode. It does nothing in replay
+// mode.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0644)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeRecord {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recorded := RecordedResponse{
+		Status: resp.StatusCode,
+		Header: scrubHeader(resp.Header),
+	}
+	body := r.scrubBody(respBody)
+	if utf8.Valid(body) {
+		recorded.Body = string(body)
+	} else {
+		recorded.Body = base64.StdEncoding.EncodeToString(body)
+		recorded.Encoding = "base64"
+	}
+
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  scrubQuery(req.URL.Query()),
+			Body:   string(r.scrubBody(reqBody)),
+		},
+		Response: recorded,
+	})
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	query := scrubQuery(req.URL.Query())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || in.Request.Method != req.Method || in.Request.Path != req.URL.Path || in.Request.Query != query {
+			continue
+		}
+		r.used[i] = true
+
+		body := []byte(in.Response.Body)
+		if in.Response.Encoding == "base64" {
+			var err error
+			if body, err = base64.StdEncoding.DecodeString(in.Response.Body); err != nil {
+				return nil, err
+			}
+		}
+
+		header := in.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.Status, http.StatusText(in.Response.Status)),
+			StatusCode:    in.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s?%s", ErrInteractionNotFound, req.Method, req.URL.Path, query)
+}
+
+// scrubHeader drops headers that may carry credentials or that no longer
+// match the scrubbed body.
+func scrubHeader(h http.Header) http.Header {
+	c := h.Clone()
+	for _, k := range []string{"Authorization", "Set-Cookie", "Date", "Content-Length"} {
+		c.Del(k)
+	}
+	if len(c) == 0 {
+		return nil
+	}
+	return c
+}
+
+// scrubQuery returns the canonical encoding of q, as produced by the client,
+// with the access token removed and phone numbers redacted so recorded and
+// replayed requests match regardless of the numbers used.
+func scrubQuery(q url.Values) string {
+	q.Del("access_token")
+	for k := range q {
+		if isNumberKey(k) {
+			for i := range q[k] {
+				if q[k][i] != "" {
+					q[k][i] = "REDACTED"
+				}
+			}
+		}
+	}
+	return q.Encode()
+}
+
+// scrubBody replaces phone numbers in a JSON body with fake numbers. The same
+// number is always replaced with the same fake number so relations between
+// calls and employees survive. Bodies that are not JSON are returned as is.
+func (r *Recorder) scrubBody(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return body
+	}
+
+	data, err := json.Marshal(r.scrubValue(v))
+	if err != nil {
+		return body
+	}
+	return data
+}
+
+func (r *Recorder) scrubValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		// visit keys in order so the fake numbers are assigned the same way
+		// every time a cassette is recorded
+		sort.Strings(keys)
+		for _, k := range keys {
+			val := v[k]
+			if s, ok := val.(string); ok && isNumberKey(k) && s != "" {
+				v[k] = r.fakeNumber(s)
+			} else {
+				v[k] = r.scrubValue(val)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = r.scrubValue(v[i])
+		}
+	}
+	return v
+}
+
+// fakeNumber returns the fake number standing in for number, e.g.
+// "4500000001". r.mu must be held.
+func (r *Recorder) fakeNumber(number string) string {
+	if fake, ok := r.numbers[number]; ok {
+		return fake
+	}
+	fake := fmt.Sprintf("45%08d", len(r.numbers)+1)
+	r.numbers[number] = fake
+	return fake
+}
+
+func isNumberKey(k string) bool {
+	return k == "number" || strings.HasSuffix(k, "_number")
+}
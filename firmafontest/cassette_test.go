@@ -0,0 +1,130 @@
+package firmafontest
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	firmafon "github.com/steffen25/go-firmafon"
+)
+
+func TestRecorder(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.Token = "secret"
+
+	base := time.Date(2014, 3, 21, 13, 0, 0, 0, time.UTC)
+	srv.AddEmployee(&firmafon.Employee{ID: 1, Name: "Kim", Number: "4512345678"})
+	srv.AddCall(
+		&firmafon.Call{CallUUID: "1", Direction: "incoming", FromNumber: "4587654321", ToNumber: "4512345678", StartedAt: base},
+		&firmafon.Call{CallUUID: "2", Direction: "outgoing", FromNumber: "4512345678", ToNumber: "4587654321", StartedAt: base.Add(time.Hour)},
+	)
+
+	path := filepath.Join(t.TempDir(), "testdata", "cassette.json")
+	rec, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+
+	client := firmafon.NewClientWithHTTPClient("secret", rec.HTTPClient())
+	client.BaseURL = srv.Client().BaseURL
+
+	opt := &firmafon.CallsListOptions{Number: "4587654321", Limit: "10"}
+	recorded, _, err := client.Calls.GetAll(opt)
+	if err != nil {
+		t.Fatalf("GetAll returned error: %v", err)
+	}
+	if recorded[0].FromNumber != "4512345678" {
+		t.Errorf("recording changed the response seen by the client: %+v", recorded[0])
+	}
+	if _, _, err := client.Employees.GetById(1); err != nil {
+		t.Fatalf("GetById returned error: %v", err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading cassette: %v", err)
+	}
+	for _, leak := range []string{"secret", "12345678", "87654321"} {
+		if strings.Contains(string(data), leak) {
+			t.Errorf("cassette contains %q:\n%s", leak, data)
+		}
+	}
+
+	// replay without a server, using different but equivalent numbers
+	srv.Close()
+	rep, err := NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	client = firmafon.NewClientWithHTTPClient("other", rep.HTTPClient())
+	client.BaseURL = srv.Client().BaseURL
+
+	calls, _, err := client.Calls.GetAll(&firmafon.CallsListOptions{Limit: "10", Number: "+45 11 11 11 11"})
+	if err != nil {
+		t.Fatalf("replayed GetAll returned error: %v", err)
+	}
+	got := []string{}
+	for _, c := range calls {
+		got = append(got, c.CallUUID+":"+c.FromNumber+">"+c.ToNumber)
+	}
+	want := []string{"2:4500000001>4500000002", "1:4500000002>4500000001"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("replayed calls = %v, want %v", got, want)
+	}
+
+	emp, _, err := client.Employees.GetById(1)
+	if err != nil {
+		t.Fatalf("replayed GetById returned error: %v", err)
+	}
+	if want := (&firmafon.Employee{ID: 1, Name: "Kim", Number: "4500000001"}); !reflect.DeepEqual(emp, want) {
+		t.Errorf("replayed employee = %+v, want %+v", emp, want)
+	}
+
+	// each interaction is replayed once
+	_, _, err = client.Employees.GetById(1)
+	if !errors.Is(err, ErrInteractionNotFound) {
+		t.Errorf("GetById returned %v, want ErrInteractionNotFound", err)
+	}
+	if _, _, err := client.Calls.GetAll(&firmafon.CallsListOptions{Limit: "5"}); !errors.Is(err, ErrInteractionNotFound) {
+		t.Errorf("GetAll with a different query returned %v, want ErrInteractionNotFound", err)
+	}
+}
+
+func TestRecorder_Errors(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.InjectFault(Fault{Status: http.StatusInternalServerError})
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	rec, _ := NewRecorder(path, ModeRecord)
+	client := firmafon.NewClientWithHTTPClient("", rec.HTTPClient())
+	client.BaseURL = srv.Client().BaseURL
+
+	if _, _, err := client.Employees.All(); err == nil {
+		t.Fatal("All expected an error but got none")
+	}
+	rec.Stop()
+
+	rep, _ := NewRecorder(path, ModeReplay)
+	client = firmafon.NewClientWithHTTPClient("", rep.HTTPClient())
+	client.BaseURL = srv.Client().BaseURL
+
+	_, resp, err := client.Employees.All()
+	var errResp *firmafon.ErrorResponse
+	if !errors.As(err, &errResp) || resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("replayed All returned %v, want a 500 error", err)
+	}
+
+	if _, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), ModeReplay); err == nil {
+		t.Error("NewRecorder for a missing cassette expected an error but got none")
+	}
+}
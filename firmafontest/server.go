@@ -0,0 +1,509 @@
+// Package firmafontest provides an in-memory fake of the Firmafon API for
+// testing code that uses the firmafon package.
+//
+//	srv := firmafontest.NewServer()
+//	defer srv.Close()
+//
+//	srv.AddEmployee(&firmafon.Employee{ID: 1, Name: "Kim"})
+//	client := srv.Client()
+//
+//	emps, _, err := client.Employees.All()
+package firmafontest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	firmafon "github.com/steffen25/go-firmafon"
+)
+
+// BasePath is the path the fake API is served under.
+const BasePath = "/api/v2/"
+
+// Request is a request received by the Server.
+type Request struct {
+	Method string
+	// Path is relative to BasePath, e.g. "employees/1".
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// SMS is a message sent with EmployeesService.SendSMS.
+type SMS struct {
+	EmployeeID int
+	Body       string
+}
+
+// Fault makes the Server delay or fail matching requests.
+type Fault struct {
+	// Method and Path select the requests the fault applies to. Path is
+	// relative to BasePath and matches as a prefix. Empty values match any
+	// request.
+	Method string
+	Path   string
+
+	// Latency delays the response.
+	Latency time.Duration
+
+	// Status fails the request with the given status code, e.g.
+	// http.StatusUnauthorized, http.StatusTooManyRequests or
+	// http.StatusInternalServerError. Zero only applies Latency.
+	Status int
+
+	// Times limits how many requests the fault applies to. Zero means all.
+	Times int
+}
+
+type fault struct {
+	Fault
+	hits int
+}
+
+// Server is a stateful fake of the Firmafon API. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	// Token is the access token requests must carry. If empty any token is
+	// accepted.
+	Token string
+
+	mu            sync.Mutex
+	employees     map[int]*firmafon.Employee
+	calls         []*firmafon.Call
+	messages      []SMS
+	authenticated int
+	faults        []*fault
+	requests      []Request
+}
+
+// NewServer starts and returns a new Server. Call Close when done.
+func NewServer() *Server {
+	s := &Server{employees: make(map[int]*firmafon.Employee)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Client returns a firmafon.Client talking to the server.
+func (s *Server) Client() *firmafon.Client {
+	c := firmafon.NewClient(s.Token)
+	c.BaseURL, _ = url.Parse(s.URL + BasePath)
+
+	return c
+}
+
+// AddEmployee adds or replaces an employee. The first employee added is the
+// authenticated employee unless SetAuthenticated is called.
+func (s *Server) AddEmployee(e *firmafon.Employee) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.employees[e.ID] = copyEmployee(e)
+	if s.authenticated == 0 {
+		s.authenticated = e.ID
+	}
+}
+
+// Employee returns the current state of an employee, or nil.
+func (s *Server) Employee(id int) *firmafon.Employee {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.employees[id]
+	if !ok {
+		return nil
+	}
+
+	return copyEmployee(e)
+}
+
+// SetAuthenticated sets the employee returned by EmployeesService.Authenticated.
+func (s *Server) SetAuthenticated(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.authenticated = id
+}
+
+// AddCall adds calls to the call log.
+func (s *Server) AddCall(calls ...*firmafon.Call) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range calls {
+		cc := *c
+		s.calls = append(s.calls, &cc)
+	}
+}
+
+// Messages returns the SMS messages sent so far.
+func (s *Server) Messages() []SMS {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]SMS(nil), s.messages...)
+}
+
+// InjectFault adds a fault. Faults are checked in the order they were added.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault{Fault: f})
+}
+
+// ClearFaults removes all faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// TB is the subset of testing.TB used by the assertions.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// RequestCount returns the number of requests received for method and path.
+func (s *Server) RequestCount(method, path string) int {
+	n := 0
+	for _, r := range s.Requests() {
+		if r.Method == method && r.Path == path {
+			n++
+		}
+	}
+
+	return n
+}
+
+// AssertRequested fails t if no request was received for method and path.
+func (s *Server) AssertRequested(t TB, method, path string) {
+	t.Helper()
+	if s.RequestCount(method, path) == 0 {
+		t.Errorf("firmafontest: expected a %s %s request, got none", method, path)
+	}
+}
+
+// AssertRequestCount fails t unless exactly n requests were received for
+// method and path.
+func (s *Server) AssertRequestCount(t TB, method, path string, n int) {
+	t.Helper()
+	if got := s.RequestCount(method, path); got != n {
+		t.Errorf("firmafontest: got %d %s %s requests, want %d", got, method, path, n)
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	path := strings.TrimPrefix(r.URL.Path, BasePath)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	f := s.matchFault(r.Method, path)
+	s.mu.Unlock()
+
+	if f != nil {
+		if f.Latency > 0 {
+			time.Sleep(f.Latency)
+		}
+		if f.Status != 0 {
+			if f.Status == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "1")
+			}
+			writeError(w, f.Status)
+			return
+		}
+	}
+
+	if !strings.HasPrefix(r.URL.Path, BasePath) {
+		writeError(w, http.StatusNotFound)
+		return
+	}
+	if s.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.Token {
+		writeError(w, http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case r.Method == "GET" && path == "employees":
+		s.listEmployees(w)
+	case r.Method == "GET" && path == "employee":
+		s.getEmployee(w, strconv.Itoa(s.authenticated))
+	case r.Method == "GET" && len(parts) == 2 && parts[0] == "employees":
+		s.getEmployee(w, parts[1])
+	case r.Method == "PUT" && len(parts) == 2 && parts[0] == "employees":
+		s.updateEmployee(w, parts[1], body)
+	case r.Method == "POST" && len(parts) == 3 && parts[0] == "employees" && parts[2] == "message":
+		s.sendSMS(w, parts[1], body)
+	case r.Method == "GET" && path == "calls":
+		s.listCalls(w, r.URL.Query())
+	case r.Method == "GET" && len(parts) == 2 && parts[0] == "calls":
+		s.getCall(w, parts[1])
+	default:
+		writeError(w, http.StatusNotFound)
+	}
+}
+
+// matchFault returns the first fault matching the request and counts the hit.
+// s.mu must be held.
+func (s *Server) matchFault(method, path string) *Fault {
+	for _, f := range s.faults {
+		if f.Method != "" && f.Method != method {
+			continue
+		}
+		if !strings.HasPrefix(path, f.Path) {
+			continue
+		}
+		if f.Times > 0 && f.hits >= f.Times {
+			continue
+		}
+		f.hits++
+		return &f.Fault
+	}
+
+	return nil
+}
+
+func (s *Server) listEmployees(w http.ResponseWriter) {
+	ids := make([]int, 0, len(s.employees))
+	for id := range s.employees {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	emps := make([]*firmafon.Employee, len(ids))
+	for i, id := range ids {
+		emps[i] = s.employees[id]
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"employees": emps})
+}
+
+func (s *Server) employee(w http.ResponseWriter, id string) *firmafon.Employee {
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound)
+		return nil
+	}
+	e, ok := s.employees[n]
+	if !ok {
+		writeError(w, http.StatusNotFound)
+		return nil
+	}
+
+	return e
+}
+
+func (s *Server) getEmployee(w http.ResponseWriter, id string) {
+	if e := s.employee(w, id); e != nil {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"employee": e})
+	}
+}
+
+// updateEmployee applies the fields present in the request to the employee,
+// leaving the rest unchanged.
+func (s *Server) updateEmployee(w http.ResponseWriter, id string, body []byte) {
+	e := s.employee(w, id)
+	if e == nil {
+		return
+	}
+
+	var req struct {
+		Employee map[string]json.RawMessage `json:"employee"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil || req.Employee == nil {
+		writeError(w, http.StatusUnprocessableEntity)
+		return
+	}
+	delete(req.Employee, "id")
+
+	current, _ := json.Marshal(e)
+	var fields map[string]json.RawMessage
+	json.Unmarshal(current, &fields)
+	for k, v := range req.Employee {
+		fields[k] = v
+	}
+
+	merged, _ := json.Marshal(fields)
+	updated := new(firmafon.Employee)
+	if err := json.Unmarshal(merged, updated); err != nil {
+		writeError(w, http.StatusUnprocessableEntity)
+		return
+	}
+	s.employees[e.ID] = updated
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"employee": updated})
+}
+
+func (s *Server) sendSMS(w http.ResponseWriter, id string, body []byte) {
+	e := s.employee(w, id)
+	if e == nil {
+		return
+	}
+
+	var req struct {
+		Message struct {
+			Body string `json:"body"`
+		} `json:"message"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil || req.Message.Body == "" {
+		writeError(w, http.StatusUnprocessableEntity)
+		return
+	}
+	s.messages = append(s.messages, SMS{EmployeeID: e.ID, Body: req.Message.Body})
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"sent": 1})
+}
+
+func (s *Server) listCalls(w http.ResponseWriter, q url.Values) {
+	filter, err := newCallFilter(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest)
+		return
+	}
+
+	calls := []*firmafon.Call{}
+	for _, c := range s.calls {
+		if filter.match(c) {
+			calls = append(calls, c)
+		}
+	}
+	sort.SliceStable(calls, func(i, j int) bool {
+		return calls[i].StartedAt.After(calls[j].StartedAt)
+	})
+	if filter.limit > 0 && len(calls) > filter.limit {
+		calls = calls[:filter.limit]
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"calls": calls})
+}
+
+func (s *Server) getCall(w http.ResponseWriter, uuid string) {
+	for _, c := range s.calls {
+		if c.CallUUID == uuid {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"call": c})
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound)
+}
+
+// callFilter implements the filters of firmafon.CallsListOptions. Empty
+// parameters are ignored, as the client sends every option.
+type callFilter struct {
+	endpoint, direction, status string
+	number                      firmafon.PhoneNumber
+	limit                       int
+	startedFrom, startedTo      time.Time
+	endedFrom, endedTo          time.Time
+}
+
+func newCallFilter(q url.Values) (*callFilter, error) {
+	f := &callFilter{
+		endpoint:  q.Get("endpoint"),
+		direction: q.Get("direction"),
+		status:    q.Get("status"),
+		number:    firmafon.PhoneNumber(q.Get("number")),
+	}
+
+	if l := q.Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil {
+			return nil, err
+		}
+		f.limit = n
+	}
+
+	times := []struct {
+		param string
+		t     *time.Time
+	}{
+		{"started_at_gt_or_eq", &f.startedFrom},
+		{"started_at_lt_or_eq", &f.startedTo},
+		{"ended_at_gt_or_eq", &f.endedFrom},
+		{"ended_at_lt_or_eq", &f.endedTo},
+	}
+	for _, p := range times {
+		v := q.Get(p.param)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", p.param, err)
+		}
+		*p.t = t
+	}
+
+	return f, nil
+}
+
+func (f *callFilter) match(c *firmafon.Call) bool {
+	switch {
+	case f.endpoint != "" && c.Endpoint != f.endpoint,
+		f.direction != "" && c.Direction != f.direction,
+		f.status != "" && c.Status != f.status,
+		f.number != "" && !f.number.Equal(firmafon.PhoneNumber(c.FromNumber)) && !f.number.Equal(firmafon.PhoneNumber(c.ToNumber)),
+		!f.startedFrom.IsZero() && c.StartedAt.Before(f.startedFrom),
+		!f.startedTo.IsZero() && c.StartedAt.After(f.startedTo),
+		!f.endedFrom.IsZero() && c.EndedAt.Before(f.endedFrom),
+		!f.endedTo.IsZero() && c.EndedAt.After(f.endedTo):
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int) {
+	text := http.StatusText(status)
+	writeJSON(w, status, map[string]string{
+		"status":  fmt.Sprintf("%d %s", status, strings.ToLower(text)),
+		"message": strings.ToLower(text),
+	})
+}
+
+func copyEmployee(e *firmafon.Employee) *firmafon.Employee {
+	b, _ := json.Marshal(e)
+	c := new(firmafon.Employee)
+	json.Unmarshal(b, c)
+
+	return c
+}
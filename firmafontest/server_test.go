@@ -0,0 +1,203 @@
+package firmafontest
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	firmafon "github.com/steffen25/go-firmafon"
+)
+
+func TestServer_Employees(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.AddEmployee(&firmafon.Employee{ID: 2, Name: "Karsten Kollega"})
+	srv.AddEmployee(&firmafon.Employee{ID: 1, Name: "Kim Kontakt", Number: "4512345678"})
+	client := srv.Client()
+
+	emps, _, err := client.Employees.All()
+	if err != nil {
+		t.Fatalf("All returned error: %v", err)
+	}
+	want := []*firmafon.Employee{
+		{ID: 1, Name: "Kim Kontakt", Number: "4512345678"},
+		{ID: 2, Name: "Karsten Kollega"},
+	}
+	if !reflect.DeepEqual(emps, want) {
+		t.Errorf("All returned %+v, want %+v", emps, want)
+	}
+
+	me, _, err := client.Employees.Authenticated()
+	if err != nil {
+		t.Fatalf("Authenticated returned error: %v", err)
+	}
+	if me.ID != 2 {
+		t.Errorf("Authenticated returned employee %d, want 2", me.ID)
+	}
+
+	updated, _, err := client.Employees.Update(&firmafon.Employee{ID: 1, DoNotDisturb: true})
+	if err != nil {
+		t.Fatalf("Update returned error: %v", err)
+	}
+	wantUpdated := &firmafon.Employee{ID: 1, Name: "Kim Kontakt", Number: "4512345678", DoNotDisturb: true}
+	if !reflect.DeepEqual(updated, wantUpdated) {
+		t.Errorf("Update returned %+v, want %+v", updated, wantUpdated)
+	}
+	if got := srv.Employee(1); !reflect.DeepEqual(got, wantUpdated) {
+		t.Errorf("server state is %+v, want %+v", got, wantUpdated)
+	}
+
+	if _, _, err := client.Employees.SendSMS(&firmafon.Employee{ID: 1}, "hello"); err != nil {
+		t.Fatalf("SendSMS returned error: %v", err)
+	}
+	if got, want := srv.Messages(), []SMS{{EmployeeID: 1, Body: "hello"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Messages = %+v, want %+v", got, want)
+	}
+
+	if _, _, err := client.Employees.GetById(3); err == nil {
+		t.Error("GetById for an unknown employee expected an error but got none")
+	}
+
+	srv.AssertRequested(t, "PUT", "employees/1")
+	srv.AssertRequestCount(t, "GET", "employees", 1)
+}
+
+func TestServer_Calls(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	base := time.Date(2014, 3, 21, 13, 0, 0, 0, time.UTC)
+	srv.AddCall(
+		&firmafon.Call{CallUUID: "1", Endpoint: "Reception#1", Direction: "incoming", Status: "answered",
+			FromNumber: "4512345678", ToNumber: "4571999999", StartedAt: base, EndedAt: base.Add(time.Minute)},
+		&firmafon.Call{CallUUID: "2", Endpoint: "Reception#1", Direction: "incoming", Status: "missed",
+			FromNumber: "4511223344", ToNumber: "4571999999", StartedAt: base.Add(time.Hour), EndedAt: base.Add(time.Hour)},
+		&firmafon.Call{CallUUID: "3", Endpoint: "Employee#1", Direction: "outgoing", Status: "answered",
+			FromNumber: "4571999999", ToNumber: "4512345678", StartedAt: base.Add(2 * time.Hour), EndedAt: base.Add(2 * time.Hour)},
+	)
+	client := srv.Client()
+
+	tests := []struct {
+		opt  *firmafon.CallsListOptions
+		want []string
+	}{
+		{nil, []string{"3", "2", "1"}},
+		{&firmafon.CallsListOptions{Status: "answered"}, []string{"3", "1"}},
+		{&firmafon.CallsListOptions{Direction: "incoming", Endpoint: "Reception#1"}, []string{"2", "1"}},
+		{&firmafon.CallsListOptions{Number: "+45 12 34 56 78"}, []string{"3", "1"}},
+		{&firmafon.CallsListOptions{Limit: "1"}, []string{"3"}},
+		{&firmafon.CallsListOptions{StartedAtGtOrEq: "2014-03-21T13:30:00Z", StartedAtLtOrEq: "2014-03-21T14:30:00Z"}, []string{"2"}},
+		{&firmafon.CallsListOptions{EndedAtLtOrEq: "2014-03-21T13:01:00Z"}, []string{"1"}},
+	}
+	for _, test := range tests {
+		calls, _, err := client.Calls.GetAll(test.opt)
+		if err != nil {
+			t.Fatalf("GetAll(%+v) returned error: %v", test.opt, err)
+		}
+		got := []string{}
+		for _, c := range calls {
+			got = append(got, c.CallUUID)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("GetAll(%+v) returned calls %v, want %v", test.opt, got, test.want)
+		}
+	}
+
+	call, _, err := client.Calls.Get("2")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if call.FromNumber != "4511223344" {
+		t.Errorf("Get returned %+v", call)
+	}
+
+	if _, _, err := client.Calls.GetAll(&firmafon.CallsListOptions{StartedAtGtOrEq: "yesterday"}); err == nil {
+		t.Error("GetAll with an invalid time expected an error but got none")
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	srv.AddEmployee(&firmafon.Employee{ID: 1})
+	client := srv.Client()
+
+	srv.InjectFault(Fault{Method: "GET", Path: "employees", Status: http.StatusTooManyRequests, Times: 1})
+	_, resp, err := client.Employees.All()
+	var errResp *firmafon.ErrorResponse
+	if !errors.As(err, &errResp) || resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("All returned %v, want a 429 error", err)
+	}
+	if got := resp.Header.Get("Retry-After"); got == "" {
+		t.Error("429 response has no Retry-After header")
+	}
+
+	// the fault only applied once
+	if _, _, err := client.Employees.All(); err != nil {
+		t.Errorf("All returned error: %v", err)
+	}
+
+	srv.InjectFault(Fault{Status: http.StatusUnauthorized})
+	_, _, err = client.Employees.GetById(1)
+	var authErr *firmafon.AuthError
+	if !errors.As(err, &authErr) || authErr.Message != "unauthorized" {
+		t.Errorf("GetById returned %v, want an AuthError", err)
+	}
+
+	srv.ClearFaults()
+	srv.InjectFault(Fault{Latency: 50 * time.Millisecond})
+	start := time.Now()
+	if _, _, err := client.Employees.GetById(1); err != nil {
+		t.Errorf("GetById returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("request took %v, want at least 50ms", elapsed)
+	}
+}
+
+func TestServer_Token(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.Token = "secret"
+
+	client := srv.Client()
+	if _, _, err := client.Calls.GetAll(nil); err != nil {
+		t.Errorf("GetAll with the right token returned error: %v", err)
+	}
+
+	client.AccessToken = "wrong"
+	if _, _, err := client.Calls.GetAll(nil); err == nil {
+		t.Error("GetAll with the wrong token expected an error but got none")
+	}
+
+	reqs := srv.Requests()
+	if len(reqs) != 2 || reqs[0].Header.Get("Authorization") != "Bearer secret" {
+		t.Errorf("Requests = %+v", reqs)
+	}
+}
+
+type recordingTB struct {
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, format)
+}
+
+func TestServer_Assertions(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	tb := &recordingTB{}
+	srv.AssertRequested(tb, "GET", "employees")
+	srv.AssertRequestCount(tb, "GET", "employees", 1)
+	if len(tb.errors) != 2 {
+		t.Errorf("assertions reported %d failures, want 2", len(tb.errors))
+	}
+}
@@ -0,0 +1,85 @@
+package firmafon
+
+import (
+	"sort"
+	"time"
+)
+
+// Holiday is a public holiday.
+type Holiday struct {
+	Date Date
+	Name string
+}
+
+// danishHolidays are the Danish public holidays relative to Easter Sunday.
+var danishHolidays = []struct {
+	days int
+	name string
+}{
+	{-3, "Skærtorsdag"},
+	{-2, "Langfredag"},
+	{0, "Påskedag"},
+	{1, "2. påskedag"},
+	{26, "Store bededag"},
+	{39, "Kristi himmelfartsdag"},
+	{49, "Pinsedag"},
+	{50, "2. pinsedag"},
+}
+
+// DanishPublicHolidays returns the Danish public holidays in year, ordered
+// by date. Store bededag is included until 2023, when it was abolished.
+// Days that are commonly but not officially holidays, such as Grundlovsdag
+// and Christmas Eve, are not included; add them as an OpeningException.
+func DanishPublicHolidays(year int) []Holiday {
+	holidays := []Holiday{
+		{Date{year, time.January, 1}, "Nytårsdag"},
+		{Date{year, time.December, 25}, "Juledag"},
+		{Date{year, time.December, 26}, "2. juledag"},
+	}
+
+	easter := easterSunday(year)
+	for _, h := range danishHolidays {
+		if h.name == "Store bededag" && year >= 2024 {
+			continue
+		}
+		holidays = append(holidays, Holiday{easter.AddDays(h.days), h.name})
+	}
+
+	sort.Slice(holidays, func(i, j int) bool {
+		return holidays[i].Date.In(time.UTC).Before(holidays[j].Date.In(time.UTC))
+	})
+
+	return holidays
+}
+
+// DanishPublicHoliday returns the Danish public holiday on d, if any.
+func DanishPublicHoliday(d Date) (Holiday, bool) {
+	for _, h := range DanishPublicHolidays(d.Year) {
+		if h.Date == d {
+			return h, true
+		}
+	}
+
+	return Holiday{}, false
+}
+
+// easterSunday returns the date of Easter Sunday in the Gregorian calendar,
+// computed with the anonymous Gregorian algorithm.
+func easterSunday(year int) Date {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return Date{year, time.Month(month), day}
+}
@@ -0,0 +1,63 @@
+package firmafon
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEasterSunday(t *testing.T) {
+	tests := map[int]Date{
+		2000: {2000, time.April, 23},
+		2019: {2019, time.April, 21},
+		2024: {2024, time.March, 31},
+		2025: {2025, time.April, 20},
+		2038: {2038, time.April, 25},
+		2285: {2285, time.March, 22},
+	}
+
+	for year, want := range tests {
+		if got := easterSunday(year); got != want {
+			t.Errorf("easterSunday(%d) returned %v, want %v", year, got, want)
+		}
+	}
+}
+
+func TestDanishPublicHolidays(t *testing.T) {
+	got := DanishPublicHolidays(2023)
+
+	want := []Holiday{
+		{Date{2023, time.January, 1}, "Nytårsdag"},
+		{Date{2023, time.April, 6}, "Skærtorsdag"},
+		{Date{2023, time.April, 7}, "Langfredag"},
+		{Date{2023, time.April, 9}, "Påskedag"},
+		{Date{2023, time.April, 10}, "2. påskedag"},
+		{Date{2023, time.May, 5}, "Store bededag"},
+		{Date{2023, time.May, 18}, "Kristi himmelfartsdag"},
+		{Date{2023, time.May, 28}, "Pinsedag"},
+		{Date{2023, time.May, 29}, "2. pinsedag"},
+		{Date{2023, time.December, 25}, "Juledag"},
+		{Date{2023, time.December, 26}, "2. juledag"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DanishPublicHolidays(2023) returned %v, want %v", got, want)
+	}
+
+	// Store bededag was abolished from 2024
+	for _, h := range DanishPublicHolidays(2024) {
+		if h.Name == "Store bededag" {
+			t.Errorf("DanishPublicHolidays(2024) returned %v", h)
+		}
+	}
+}
+
+func TestDanishPublicHoliday(t *testing.T) {
+	h, ok := DanishPublicHoliday(Date{2025, time.June, 9})
+	if want := (Holiday{Date{2025, time.June, 9}, "2. pinsedag"}); !ok || h != want {
+		t.Errorf("DanishPublicHoliday returned %v, %v, want %v, true", h, ok, want)
+	}
+
+	if h, ok := DanishPublicHoliday(Date{2025, time.June, 5}); ok {
+		t.Errorf("DanishPublicHoliday(Grundlovsdag) returned %v, want none", h)
+	}
+}
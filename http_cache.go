@@ -0,0 +1,237 @@
+package firmafon
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// defaultDecodedCacheEntries bounds the number of decoded responses a client
+// keeps in memory.
+const defaultDecodedCacheEntries = 100
+
+// HTTPCache stores response bodies for conditional requests, see
+// Client.HTTPCache. Implementations must be safe for concurrent use.
+type HTTPCache interface {
+	// Get returns the entry stored for key, if any.
+	Get(key string) (*HTTPCacheEntry, bool)
+
+	// Set stores entry for key.
+	Set(key string, entry *HTTPCacheEntry)
+}
+
+// HTTPCacheEntry is a response body stored along with its validators.
+type HTTPCacheEntry struct {
+	ETag         string
+	LastModified string
+	Body         []byte
+}
+
+// MemoryHTTPCache is an HTTPCache that keeps entries in memory. Entries are
+// never evicted, which is fine for the handful of URLs a client polls.
+type MemoryHTTPCache struct {
+	mu      sync.RWMutex
+	entries map[string]*HTTPCacheEntry
+}
+
+// NewMemoryHTTPCache returns an empty MemoryHTTPCache.
+func NewMemoryHTTPCache() *MemoryHTTPCache {
+	return &MemoryHTTPCache{entries: make(map[string]*HTTPCacheEntry)}
+}
+
+func (c *MemoryHTTPCache) Get(key string) (*HTTPCacheEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.entries[key]
+	return e, ok
+}
+
+func (c *MemoryHTTPCache) Set(key string, entry *HTTPCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = entry
+}
+
+// conditional adds the conditional headers for req if a cached entry exists.
+// It returns the cache key, which is empty if req can't be cached, and the
+// cached entry, if any.
+func (c *Client) conditional(req *http.Request) (string, *HTTPCacheEntry) {
+	if c.HTTPCache == nil || req.Method != http.MethodGet || req.URL == nil {
+		return "", nil
+	}
+	if req.Header.Get("Range") != "" {
+		return "", nil
+	}
+	if req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		// the caller is doing its own conditional request
+		return "", nil
+	}
+
+	key := httpCacheKey(req)
+	entry, ok := c.HTTPCache.Get(key)
+	if !ok {
+		return key, nil
+	}
+
+	if entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.LastModified)
+	}
+
+	return key, entry
+}
+
+// httpCacheKey returns the cache key for req. The key includes a hash of the
+// Authorization header so clients for different companies can share a cache
+// without seeing each other's data.
+func httpCacheKey(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return hex.EncodeToString(sum[:8]) + " " + req.URL.String()
+}
+
+// newHTTPCacheEntry returns an entry holding the validators of resp, or nil
+// if resp has none.
+func newHTTPCacheEntry(resp *http.Response) *HTTPCacheEntry {
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return nil
+	}
+
+	return &HTTPCacheEntry{ETag: etag, LastModified: lastModified}
+}
+
+// decodedCache keeps the decoded values of cached responses, so a 304 is
+// served without decoding the body stored in Client.HTTPCache again. Values
+// are deep copied in and out, so callers can't modify the cached values.
+// The least recently used value is evicted first. A nil *decodedCache
+// caches nothing.
+type decodedCache struct {
+	mu      sync.Mutex
+	max     int
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+type decodedEntry struct {
+	key          string
+	etag         string
+	lastModified string
+	value        reflect.Value
+}
+
+func newDecodedCache(max int) *decodedCache {
+	return &decodedCache{max: max, lru: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// load sets the value v points to to the value decoded from entry, and
+// reports whether it did. Values decoded from another version of the
+// resource or into another type are not used.
+func (dc *decodedCache) load(key string, entry *HTTPCacheEntry, v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	if dc == nil || rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return false
+	}
+
+	dc.mu.Lock()
+	el, ok := dc.entries[key]
+	if !ok {
+		dc.mu.Unlock()
+		return false
+	}
+	e := el.Value.(*decodedEntry)
+	if e.etag != entry.ETag || e.lastModified != entry.LastModified || e.value.Type() != rv.Elem().Type() {
+		dc.mu.Unlock()
+		return false
+	}
+	dc.lru.MoveToFront(el)
+	dc.mu.Unlock()
+
+	// stored values are never modified, so they can be copied unlocked
+	rv.Elem().Set(deepCopy(e.value))
+	return true
+}
+
+// store keeps a copy of the value v points to, decoded from entry.
+func (dc *decodedCache) store(key string, entry *HTTPCacheEntry, v interface{}) {
+	rv := reflect.ValueOf(v)
+	if dc == nil || rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return
+	}
+
+	e := &decodedEntry{
+		key:          key,
+		etag:         entry.ETag,
+		lastModified: entry.LastModified,
+		value:        deepCopy(rv.Elem()),
+	}
+
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	if el, ok := dc.entries[key]; ok {
+		el.Value = e
+		dc.lru.MoveToFront(el)
+		return
+	}
+	dc.entries[key] = dc.lru.PushFront(e)
+	for dc.lru.Len() > dc.max {
+		el := dc.lru.Back()
+		dc.lru.Remove(el)
+		delete(dc.entries, el.Value.(*decodedEntry).key)
+	}
+}
+
+// deepCopy returns a copy of v that shares no pointers, slices or maps with
+// it. Unexported struct fields are copied as they are.
+func deepCopy(v reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			c.Set(deepCopy(v.Elem()).Addr())
+		}
+	case reflect.Interface:
+		if !v.IsNil() {
+			c.Set(deepCopy(v.Elem()))
+		}
+	case reflect.Slice:
+		if !v.IsNil() {
+			c.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				c.Index(i).Set(deepCopy(v.Index(i)))
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+	case reflect.Map:
+		if !v.IsNil() {
+			c.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+			iter := v.MapRange()
+			for iter.Next() {
+				c.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+			}
+		}
+	case reflect.Struct:
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+	default:
+		c.Set(v)
+	}
+
+	return c
+}
@@ -0,0 +1,299 @@
+package firmafon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+type cachedBody struct {
+	A string
+}
+
+func TestDo_conditionalETag(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/employees", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"employees":[{"id": 1}, {"id": 2}]}`)
+	})
+
+	client.HTTPCache = NewMemoryHTTPCache()
+	want := []*Employee{{ID: 1}, {ID: 2}}
+
+	emps, resp, err := client.Employees.All()
+	if err != nil {
+		t.Fatalf("All returned error: %v", err)
+	}
+	if resp.Cached {
+		t.Error("first response is marked as cached")
+	}
+	if !reflect.DeepEqual(emps, want) {
+		t.Errorf("All returned %+v, want %+v", emps, want)
+	}
+
+	emps, resp, err = client.Employees.All()
+	if err != nil {
+		t.Fatalf("All returned error: %v", err)
+	}
+	if !resp.Cached {
+		t.Error("304 response is not marked as cached")
+	}
+	if resp.StatusCode != http.StatusNotModified {
+		t.Errorf("StatusCode = %d, want %d", resp.StatusCode, http.StatusNotModified)
+	}
+	if !reflect.DeepEqual(emps, want) {
+		t.Errorf("All returned %+v from the cache, want %+v", emps, want)
+	}
+	if requests != 2 {
+		t.Errorf("API was called %d times, want 2", requests)
+	}
+}
+
+func TestDo_conditionalLastModified(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	const lastModified = "Fri, 21 Mar 2014 13:59:04 GMT"
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified)
+		fmt.Fprint(w, `{"A":"a"}`)
+	})
+
+	client.HTTPCache = NewMemoryHTTPCache()
+	for i := 0; i < 2; i++ {
+		body := new(cachedBody)
+		req, _ := client.NewRequest("GET", ".", nil)
+		resp, err := client.Do(req, body)
+		if err != nil {
+			t.Fatalf("Do returned unexpected error: %v", err)
+		}
+		if got, want := resp.Cached, i == 1; got != want {
+			t.Errorf("request %d: Cached = %v, want %v", i, got, want)
+		}
+		if want := (&cachedBody{"a"}); !reflect.DeepEqual(body, want) {
+			t.Errorf("request %d: Response body = %v, want %v", i, body, want)
+		}
+	}
+}
+
+func TestDo_conditionalWriterNotCached(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("If-None-Match"); got != "" {
+			t.Errorf("If-None-Match = %q, want none", got)
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, "test")
+	})
+
+	cache := NewMemoryHTTPCache()
+	client.HTTPCache = cache
+	for i := 0; i < 2; i++ {
+		var b bytes.Buffer
+		req, _ := client.NewRequest("GET", ".", nil)
+		resp, err := client.Do(req, &b)
+		if err != nil {
+			t.Fatalf("Do returned unexpected error: %v", err)
+		}
+		if resp.Cached {
+			t.Errorf("request %d: response to a writer is marked as cached", i)
+		}
+		if got, want := b.String(), "test"; got != want {
+			t.Errorf("request %d: Response body = %v, want %v", i, got, want)
+		}
+	}
+	if n := len(cache.entries); n != 0 {
+		t.Errorf("cache holds %d entries, want 0", n)
+	}
+}
+
+func TestDo_conditionalSkipped(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("If-None-Match"); got != "" {
+			t.Errorf("If-None-Match = %q, want none", got)
+		}
+		fmt.Fprint(w, `{}`)
+	})
+
+	client.HTTPCache = NewMemoryHTTPCache()
+	for i := 0; i < 2; i++ {
+		// responses without validators and non-GET requests aren't cached
+		for _, method := range []string{"GET", "PUT"} {
+			req, _ := client.NewRequest(method, ".", nil)
+			if _, err := client.Do(req, nil); err != nil {
+				t.Fatalf("Do returned unexpected error: %v", err)
+			}
+		}
+	}
+}
+
+func TestDo_conditionalPerToken(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			t.Errorf("conditional request sent for a different access token")
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{}`)
+	})
+
+	client.HTTPCache = NewMemoryHTTPCache()
+	for _, token := range []string{"a", "b"} {
+		client.AccessToken = Credential(token)
+		req, _ := client.NewRequest("GET", ".", nil)
+		if _, err := client.Do(req, new(cachedBody)); err != nil {
+			t.Fatalf("Do returned unexpected error: %v", err)
+		}
+	}
+}
+
+// countingBody counts how often it is decoded.
+type countingBody struct {
+	A string
+}
+
+var countingBodyDecodes int32
+
+func (b *countingBody) UnmarshalJSON(data []byte) error {
+	atomic.AddInt32(&countingBodyDecodes, 1)
+	var v struct{ A string }
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	b.A = v.A
+	return nil
+}
+
+func TestDo_conditionalDecodedOnce(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"A":"a"}`)
+	})
+
+	client.HTTPCache = NewMemoryHTTPCache()
+	atomic.StoreInt32(&countingBodyDecodes, 0)
+	for i := 0; i < 3; i++ {
+		body := new(countingBody)
+		req, _ := client.NewRequest("GET", ".", nil)
+		resp, err := client.Do(req, &body)
+		if err != nil {
+			t.Fatalf("Do returned unexpected error: %v", err)
+		}
+		if got, want := resp.Cached, i > 0; got != want {
+			t.Errorf("request %d: Cached = %v, want %v", i, got, want)
+		}
+		if want := (&countingBody{"a"}); !reflect.DeepEqual(body, want) {
+			t.Errorf("request %d: Response body = %v, want %v", i, body, want)
+		}
+	}
+	if got := atomic.LoadInt32(&countingBodyDecodes); got != 1 {
+		t.Errorf("body was decoded %d times, want 1", got)
+	}
+}
+
+func TestDo_conditionalDecodedCopies(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/employees", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"employees":[{"id": 1, "name": "Steffen", "employee_group_ids": [1]}]}`)
+	})
+
+	client.HTTPCache = NewMemoryHTTPCache()
+	want := []*Employee{{ID: 1, Name: "Steffen", EmployeeGroupIds: []int{1}}}
+	for i := 0; i < 3; i++ {
+		emps, _, err := client.Employees.All()
+		if err != nil {
+			t.Fatalf("All returned error: %v", err)
+		}
+		if !reflect.DeepEqual(emps, want) {
+			t.Errorf("request %d: All returned %+v, want %+v", i, emps, want)
+		}
+
+		// modifying the result must not modify the cached value
+		emps[0].Name = "Modified"
+		emps[0].EmployeeGroupIds[0] = 42
+	}
+}
+
+func TestDo_conditionalDecodeErrorNotCached(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("If-None-Match"); got != "" {
+			t.Errorf("If-None-Match = %q, want none", got)
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"A":`)
+	})
+
+	cache := NewMemoryHTTPCache()
+	client.HTTPCache = cache
+	for i := 0; i < 2; i++ {
+		req, _ := client.NewRequest("GET", ".", nil)
+		if _, err := client.Do(req, new(cachedBody)); err == nil {
+			t.Errorf("request %d: Do expected an error but got none", i)
+		}
+	}
+	if n := len(cache.entries); n != 0 {
+		t.Errorf("cache holds %d entries, want 0", n)
+	}
+}
+
+func TestDecodedCache_evicts(t *testing.T) {
+	dc := newDecodedCache(1)
+	entry := &HTTPCacheEntry{ETag: `"v1"`}
+
+	dc.store("a", entry, &cachedBody{"a"})
+	dc.store("b", entry, &cachedBody{"b"})
+
+	var body cachedBody
+	if dc.load("a", entry, &body) {
+		t.Error("load returned the evicted entry")
+	}
+	if !dc.load("b", entry, &body) || body.A != "b" {
+		t.Errorf("load returned %+v, want b", body)
+	}
+	if dc.load("b", &HTTPCacheEntry{ETag: `"v2"`}, &body) {
+		t.Error("load returned a value decoded from another version")
+	}
+	if dc.load("b", entry, new(countingBody)) {
+		t.Error("load returned a value of another type")
+	}
+}
@@ -0,0 +1,87 @@
+package firmafon
+
+import (
+	"context"
+	"io"
+)
+
+//go:generate go run github.com/matryer/moq@v0.6.0 -pkg firmafonmock -out firmafonmock/mocks.go -stub . EmployeesAPI CallsAPI CompanyAPI NumbersAPI VoicemailsAPI ContactsAPI ReceptionAPI OpeningHoursAPI
+
+// EmployeesAPI is the interface implemented by EmployeesService. Depend on it
+// instead of the concrete service to substitute a fake in tests, e.g. one
+// from the firmafonmock package.
+type EmployeesAPI interface {
+	All() ([]*Employee, *Response, error)
+	GetById(id int) (*Employee, *Response, error)
+	Update(e *Employee) (*Employee, *Response, error)
+	Authenticated() (*Employee, *Response, error)
+	SendSMS(e *Employee, msg string) (*SMSResponse, *Response, error)
+}
+
+// CallsAPI is the interface implemented by CallsService.
+type CallsAPI interface {
+	GetAll(opt *CallsListOptions) ([]*Call, *Response, error)
+	Get(uuid string) (*Call, *Response, error)
+	Recording(ctx context.Context, uuid string, w io.Writer) (*Recording, *Response, error)
+	ResumeRecording(ctx context.Context, uuid string, w io.Writer, offset int64) (*Recording, *Response, error)
+}
+
+// CompanyAPI is the interface implemented by CompanyService.
+type CompanyAPI interface {
+	Get() (*Company, *Response, error)
+	Settings() (*CompanySettings, *Response, error)
+	Subscription() (*Subscription, *Response, error)
+	Numbers() ([]*CompanyNumber, *Response, error)
+}
+
+// NumbersAPI is the interface implemented by NumbersService.
+type NumbersAPI interface {
+	List() ([]*CompanyNumber, *Response, error)
+	Get(id int) (*CompanyNumber, *Response, error)
+	SetRouting(id int, endpoint Endpoint) (*CompanyNumber, *Response, error)
+}
+
+// VoicemailsAPI is the interface implemented by VoicemailsService.
+type VoicemailsAPI interface {
+	List(opt *VoicemailsListOptions) ([]*Voicemail, *Response, error)
+	Get(id int) (*Voicemail, *Response, error)
+	MarkRead(id int) (*Voicemail, *Response, error)
+	Delete(id int) (*Response, error)
+	Audio(id int, w io.Writer) (*Response, error)
+}
+
+// ContactsAPI is the interface implemented by ContactsService.
+type ContactsAPI interface {
+	List(opt *ContactsListOptions) ([]*Contact, *Response, error)
+	SearchByNumber(number string) ([]*Contact, *Response, error)
+	Get(id int) (*Contact, *Response, error)
+	Create(c *Contact) (*Contact, *Response, error)
+	Update(c *Contact) (*Contact, *Response, error)
+	Delete(id int) (*Response, error)
+	Import(contacts []*Contact) ([]*Contact, *Response, error)
+}
+
+// ReceptionAPI is the interface implemented by ReceptionService.
+type ReceptionAPI interface {
+	Queue() ([]*QueuedCall, *Response, error)
+	SignedIn() ([]*Employee, *Response, error)
+	SignIn(employeeID int) (*Response, error)
+	SignOut(employeeID int) (*Response, error)
+}
+
+// OpeningHoursAPI is the interface implemented by OpeningHoursService.
+type OpeningHoursAPI interface {
+	Get() (*OpeningHours, *Response, error)
+	Update(h *OpeningHours) (*OpeningHours, *Response, error)
+}
+
+var (
+	_ EmployeesAPI    = (*EmployeesService)(nil)
+	_ CallsAPI        = (*CallsService)(nil)
+	_ CompanyAPI      = (*CompanyService)(nil)
+	_ NumbersAPI      = (*NumbersService)(nil)
+	_ VoicemailsAPI   = (*VoicemailsService)(nil)
+	_ ContactsAPI     = (*ContactsService)(nil)
+	_ ReceptionAPI    = (*ReceptionService)(nil)
+	_ OpeningHoursAPI = (*OpeningHoursService)(nil)
+)
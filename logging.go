@@ -0,0 +1,195 @@
+package firmafon
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// redacted replaces secrets and personal data in logs and error messages.
+const redacted = "REDACTED"
+
+// LogOptions specifies the optional parameters to Logging.
+type LogOptions struct {
+	// Level is used for successful requests. Defaults to slog.LevelInfo.
+	Level slog.Leveler
+
+	// ErrorLevel is used for failed requests. Defaults to slog.LevelError.
+	ErrorLevel slog.Leveler
+
+	// Debug additionally logs headers and bodies at slog.LevelDebug. Names,
+	// numbers, e-mail addresses, message bodies and tokens are redacted.
+	Debug bool
+}
+
+// Logging returns middleware logging every request with its operation,
+// method, sanitized URL, status and latency to logger. opt may be nil.
+func Logging(logger *slog.Logger, opt *LogOptions) Middleware {
+	if opt == nil {
+		opt = &LogOptions{}
+	}
+	level, errorLevel := opt.Level, opt.ErrorLevel
+	if level == nil {
+		level = slog.LevelInfo
+	}
+	if errorLevel == nil {
+		errorLevel = slog.LevelError
+	}
+
+	return func(next DoFunc) DoFunc {
+		return func(req *http.Request, v interface{}) (*Response, error) {
+			ctx := req.Context()
+			debug := opt.Debug && logger.Enabled(ctx, slog.LevelDebug)
+
+			var reqBody []byte
+			if debug && req.GetBody != nil {
+				if body, err := req.GetBody(); err == nil {
+					reqBody, _ = io.ReadAll(body)
+					body.Close()
+				}
+			}
+
+			start := time.Now()
+			resp, err := next(req, v)
+			latency := time.Since(start)
+
+			u := RedactURL(req.URL)
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("url", u.String()),
+				slog.Duration("latency", latency),
+			}
+			if op, ok := OperationFromContext(ctx); ok {
+				attrs = append(attrs, slog.String("operation", op.String()))
+			}
+			if resp != nil && resp.Response != nil {
+				attrs = append(attrs, slog.Int("status", resp.StatusCode))
+				if resp.Cached {
+					attrs = append(attrs, slog.Bool("cached", true))
+				}
+			}
+
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				logger.LogAttrs(ctx, errorLevel.Level(), "firmafon request failed", attrs...)
+			} else {
+				logger.LogAttrs(ctx, level.Level(), "firmafon request", attrs...)
+			}
+
+			if debug {
+				debugAttrs := []slog.Attr{
+					slog.String("method", req.Method),
+					slog.String("url", u.String()),
+					slog.Any("request_headers", sanitizeHeader(req.Header)),
+				}
+				if len(reqBody) > 0 {
+					debugAttrs = append(debugAttrs, slog.String("request_body", redactJSON(reqBody)))
+				}
+				if _, ok := v.(io.Writer); !ok && v != nil && err == nil {
+					if body, merr := json.Marshal(v); merr == nil {
+						debugAttrs = append(debugAttrs, slog.String("response_body", redactJSON(body)))
+					}
+				}
+				logger.LogAttrs(ctx, slog.LevelDebug, "firmafon request body", debugAttrs...)
+			}
+
+			return resp, err
+		}
+	}
+}
+
+// sanitizeHeader returns a copy of h with the Authorization header redacted.
+func sanitizeHeader(h http.Header) http.Header {
+	h = h.Clone()
+	if auth := h.Get("Authorization"); auth != "" {
+		scheme := ""
+		if i := strings.IndexByte(auth, ' '); i > 0 {
+			scheme = auth[:i+1]
+		}
+		h.Set("Authorization", scheme+redacted)
+	}
+
+	return h
+}
+
+// RedactURL returns a copy of u that is safe to log or export, e.g. as a
+// span attribute. User info is removed, and the access token and query
+// parameters holding personal data, such as the number filter of
+// CallsListOptions, are replaced with "REDACTED". u is not modified.
+func RedactURL(u *url.URL) *url.URL {
+	if u == nil {
+		return nil
+	}
+	c := *sanitizeURL(u)
+	c.User = nil
+
+	params := c.Query()
+	changed := false
+	for k, v := range params {
+		if isPIIKey(k) && len(v) > 0 && v[0] != "" {
+			params.Set(k, redacted)
+			changed = true
+		}
+	}
+	if changed {
+		c.RawQuery = params.Encode()
+	}
+
+	return &c
+}
+
+// piiKeys are the JSON keys whose values are redacted from logged bodies.
+// Keys containing one of these are redacted too, e.g. "from_number".
+var piiKeys = []string{"name", "number", "email", "body", "token"}
+
+func isPIIKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range piiKeys {
+		if strings.Contains(key, k) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactJSON returns data with personal data redacted. Bodies that aren't
+// JSON are replaced entirely as there is no telling what they contain.
+func redactJSON(data []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return redacted
+	}
+
+	b, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return redacted
+	}
+
+	return string(b)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if isPIIKey(k) {
+				if _, nested := e.(map[string]interface{}); !nested && e != nil {
+					v[k] = redacted
+					continue
+				}
+			}
+			v[k] = redactValue(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = redactValue(e)
+		}
+	}
+
+	return v
+}
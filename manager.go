@@ -0,0 +1,204 @@
+package firmafon
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+)
+
+// CompanyTokenProvider looks up the token of a company for a Manager.
+type CompanyTokenProvider interface {
+	CompanyToken(companyID int) (oauth2.TokenSource, error)
+}
+
+// CompanyTokenProviderFunc is an adapter to allow the use of ordinary
+// functions as a CompanyTokenProvider.
+type CompanyTokenProviderFunc func(companyID int) (oauth2.TokenSource, error)
+
+// CompanyToken calls f(companyID).
+func (f CompanyTokenProviderFunc) CompanyToken(companyID int) (oauth2.TokenSource, error) {
+	return f(companyID)
+}
+
+// ManagerOptions configures a Manager.
+type ManagerOptions struct {
+	// HTTPClient is shared by all clients so connections are pooled across
+	// companies. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// BaseURL of the API. Defaults to the Firmafon API.
+	BaseURL *url.URL
+
+	// RateLimit and Burst set the request budget of each company. A zero
+	// RateLimit means no limit. Burst defaults to 1.
+	RateLimit rate.Limit
+	Burst     int
+
+	// IdleTimeout is how long a client may go without being returned by
+	// Client before EvictIdle removes it. Zero means clients never idle out.
+	IdleTimeout time.Duration
+
+	// MaxClients evicts the least recently used client when exceeded. Zero
+	// means no limit.
+	MaxClients int
+
+	// Configure is called with every new client, e.g. to add middleware or
+	// enable the employee cache.
+	Configure func(companyID int, c *Client)
+}
+
+// Manager holds a Client per company for applications serving many Firmafon
+// companies. Clients are created on first use with the token returned by the
+// provider and share one HTTP client. It is safe for concurrent use.
+//
+// An evicted company gets a new client, and a fresh rate limit budget, the
+// next time it is requested.
+type Manager struct {
+	provider CompanyTokenProvider
+	opt      ManagerOptions
+	now      func() time.Time
+
+	mu      sync.Mutex
+	clients map[int]*managedClient
+}
+
+type managedClient struct {
+	ready    chan struct{}
+	client   *Client
+	err      error
+	lastUsed time.Time
+}
+
+// NewManager returns a Manager that looks up tokens with p. opt may be nil.
+func NewManager(p CompanyTokenProvider, opt *ManagerOptions) *Manager {
+	m := &Manager{
+		provider: p,
+		now:      time.Now,
+		clients:  make(map[int]*managedClient),
+	}
+	if opt != nil {
+		m.opt = *opt
+	}
+	if m.opt.HTTPClient == nil {
+		m.opt.HTTPClient = http.DefaultClient
+	}
+	if m.opt.Burst <= 0 {
+		m.opt.Burst = 1
+	}
+
+	return m
+}
+
+// Client returns the client of the company, creating it if needed. Errors
+// from the token provider are returned and the creation is retried on the
+// next call.
+func (m *Manager) Client(companyID int) (*Client, error) {
+	m.mu.Lock()
+	mc, ok := m.clients[companyID]
+	if ok {
+		mc.lastUsed = m.now()
+		m.mu.Unlock()
+		<-mc.ready
+		return mc.client, mc.err
+	}
+
+	mc = &managedClient{ready: make(chan struct{}), lastUsed: m.now()}
+	m.clients[companyID] = mc
+	m.evictLRU(companyID)
+	m.mu.Unlock()
+
+	mc.client, mc.err = m.newClient(companyID)
+	close(mc.ready)
+
+	if mc.err != nil {
+		m.mu.Lock()
+		if m.clients[companyID] == mc {
+			delete(m.clients, companyID)
+		}
+		m.mu.Unlock()
+	}
+
+	return mc.client, mc.err
+}
+
+func (m *Manager) newClient(companyID int) (*Client, error) {
+	ts, err := m.provider.CompanyToken(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewClientWithHTTPClient("", m.opt.HTTPClient)
+	c.tokens = tokenSourceProvider{oauth2.ReuseTokenSource(nil, ts)}
+	if m.opt.BaseURL != nil {
+		u := *m.opt.BaseURL
+		c.BaseURL = &u
+	}
+	if m.opt.RateLimit != 0 {
+		c.Use(RateLimit(rate.NewLimiter(m.opt.RateLimit, m.opt.Burst)))
+	}
+	if m.opt.Configure != nil {
+		m.opt.Configure(companyID, c)
+	}
+
+	return c, nil
+}
+
+// evictLRU removes the least recently used clients other than keep until at
+// most MaxClients remain. m.mu must be held.
+func (m *Manager) evictLRU(keep int) {
+	for m.opt.MaxClients > 0 && len(m.clients) > m.opt.MaxClients {
+		oldest, first := 0, true
+		for id, mc := range m.clients {
+			if id == keep {
+				continue
+			}
+			if first || mc.lastUsed.Before(m.clients[oldest].lastUsed) {
+				oldest, first = id, false
+			}
+		}
+		delete(m.clients, oldest)
+	}
+}
+
+// Evict removes the client of the company, e.g. after its token has been
+// revoked.
+func (m *Manager) Evict(companyID int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.clients, companyID)
+}
+
+// EvictIdle removes the clients that have not been returned by Client within
+// IdleTimeout and returns how many were removed. Call it periodically.
+func (m *Manager) EvictIdle() int {
+	if m.opt.IdleTimeout <= 0 {
+		return 0
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := 0
+	deadline := m.now().Add(-m.opt.IdleTimeout)
+	for id, mc := range m.clients {
+		if mc.lastUsed.Before(deadline) {
+			delete(m.clients, id)
+			n++
+		}
+	}
+
+	return n
+}
+
+// Len returns the number of clients held by the manager.
+func (m *Manager) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.clients)
+}
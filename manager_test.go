@@ -0,0 +1,185 @@
+package firmafon
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+)
+
+func TestManager(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	auths := map[string]int{}
+	mux.HandleFunc("/employee", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		auths[r.Header.Get("Authorization")]++
+		mu.Unlock()
+		fmt.Fprint(w, `{"employee":{"id":1}}`)
+	})
+
+	lookups := map[int]int{}
+	provider := CompanyTokenProviderFunc(func(companyID int) (oauth2.TokenSource, error) {
+		mu.Lock()
+		lookups[companyID]++
+		mu.Unlock()
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: fmt.Sprintf("token%d", companyID)}), nil
+	})
+
+	hc := &http.Client{}
+	configured := 0
+	m := NewManager(provider, &ManagerOptions{
+		HTTPClient: hc,
+		BaseURL:    client.BaseURL,
+		Configure: func(int, *Client) {
+			mu.Lock()
+			configured++
+			mu.Unlock()
+		},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(companyID int) {
+			defer wg.Done()
+			c, err := m.Client(companyID)
+			if err != nil {
+				t.Errorf("Client returned error: %v", err)
+				return
+			}
+			if _, _, err := c.Employees.Authenticated(); err != nil {
+				t.Errorf("Authenticated returned error: %v", err)
+			}
+		}(i%2 + 1)
+	}
+	wg.Wait()
+
+	if want := map[int]int{1: 1, 2: 1}; fmt.Sprint(lookups) != fmt.Sprint(want) {
+		t.Errorf("token lookups = %v, want %v", lookups, want)
+	}
+	if want := map[string]int{"Bearer token1": 5, "Bearer token2": 5}; fmt.Sprint(auths) != fmt.Sprint(want) {
+		t.Errorf("Authorization headers = %v, want %v", auths, want)
+	}
+	if configured != 2 {
+		t.Errorf("Configure called %d times, want 2", configured)
+	}
+
+	c1, _ := m.Client(1)
+	c2, _ := m.Client(2)
+	if c1.client != hc || c2.client != hc {
+		t.Error("clients do not share the HTTP client")
+	}
+	if c1.BaseURL == client.BaseURL {
+		t.Error("clients share the BaseURL of the options")
+	}
+
+	m.Evict(1)
+	if m.Len() != 1 {
+		t.Errorf("Len = %d, want 1", m.Len())
+	}
+	if c, _ := m.Client(1); c == c1 {
+		t.Error("Client returned the evicted client")
+	}
+}
+
+func TestManager_providerError(t *testing.T) {
+	fail := true
+	want := errors.New("no token")
+	m := NewManager(CompanyTokenProviderFunc(func(int) (oauth2.TokenSource, error) {
+		if fail {
+			return nil, want
+		}
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "t"}), nil
+	}), nil)
+
+	if _, err := m.Client(1); !errors.Is(err, want) {
+		t.Errorf("Client returned %v, want %v", err, want)
+	}
+	if m.Len() != 0 {
+		t.Errorf("Len = %d after a failed lookup, want 0", m.Len())
+	}
+
+	fail = false
+	if c, err := m.Client(1); err != nil || c == nil {
+		t.Errorf("Client returned %v, %v after the provider recovered", c, err)
+	}
+}
+
+func TestManager_eviction(t *testing.T) {
+	provider := CompanyTokenProviderFunc(func(int) (oauth2.TokenSource, error) {
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "t"}), nil
+	})
+	m := NewManager(provider, &ManagerOptions{IdleTimeout: time.Minute, MaxClients: 2})
+
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	m.Client(1)
+	now = now.Add(time.Second)
+	m.Client(2)
+	now = now.Add(time.Second)
+	m.Client(1)
+	now = now.Add(time.Second)
+	m.Client(3) // evicts 2, the least recently used
+
+	m.mu.Lock()
+	_, ok1 := m.clients[1]
+	_, ok2 := m.clients[2]
+	m.mu.Unlock()
+	if !ok1 || ok2 || m.Len() != 2 {
+		t.Errorf("after exceeding MaxClients have 1: %v, 2: %v, len %d; want 1 kept and 2 evicted", ok1, ok2, m.Len())
+	}
+
+	now = now.Add(time.Minute)
+	if n := m.EvictIdle(); n != 1 {
+		t.Errorf("EvictIdle removed %d clients, want 1", n)
+	}
+	now = now.Add(time.Minute)
+	if n := m.EvictIdle(); n != 1 || m.Len() != 0 {
+		t.Errorf("EvictIdle removed %d clients leaving %d, want 1 leaving 0", n, m.Len())
+	}
+}
+
+func TestManager_rateLimit(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/employee", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"employee":{"id":1}}`)
+	})
+
+	baseURL, _ := url.Parse(client.BaseURL.String())
+	m := NewManager(CompanyTokenProviderFunc(func(int) (oauth2.TokenSource, error) {
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "t"}), nil
+	}), &ManagerOptions{BaseURL: baseURL, RateLimit: rate.Every(100 * time.Millisecond)})
+
+	// each company has its own budget, so one request each is not delayed
+	start := time.Now()
+	for _, id := range []int{1, 2, 3} {
+		c, _ := m.Client(id)
+		if _, _, err := c.Employees.Authenticated(); err != nil {
+			t.Fatalf("Authenticated returned error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed >= 100*time.Millisecond {
+		t.Errorf("requests for different companies took %v, want less than 100ms", elapsed)
+	}
+
+	start = time.Now()
+	c, _ := m.Client(1)
+	if _, _, err := c.Employees.Authenticated(); err != nil {
+		t.Fatalf("Authenticated returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("second request for a company took %v, want it rate limited", elapsed)
+	}
+}
@@ -0,0 +1,55 @@
+package firmafon
+
+import (
+	"context"
+	"net/http"
+)
+
+// Operation identifies the service method that made a request.
+type Operation struct {
+	Service string // e.g. "Employees"
+	Method  string // e.g. "GetById"
+}
+
+func (o Operation) String() string {
+	return o.Service + "." + o.Method
+}
+
+type operationKey struct{}
+
+// OperationFromContext returns the operation stored in the context of a
+// request made by one of the services. Middleware can use it to find out
+// which service method is being called.
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	op, ok := ctx.Value(operationKey{}).(Operation)
+	return op, ok
+}
+
+func withOperation(req *http.Request, service, method string) *http.Request {
+	ctx := context.WithValue(req.Context(), operationKey{}, Operation{Service: service, Method: method})
+	return req.WithContext(ctx)
+}
+
+// DoFunc sends a request and decodes the response into v, like Client.Do.
+type DoFunc func(req *http.Request, v interface{}) (*Response, error)
+
+// Middleware wraps the DoFunc used by Client.Do. It can change the request
+// before calling next and inspect the decoded v, the response and the error
+// after next returns.
+type Middleware func(next DoFunc) DoFunc
+
+// Use adds middleware to the client. The first middleware added is the
+// outermost, so it sees the request first and the response last. Use must
+// not be called concurrently with requests.
+func (c *Client) Use(mw ...Middleware) {
+	c.middleware = append(c.middleware, mw...)
+}
+
+func (c *Client) chain() DoFunc {
+	do := DoFunc(c.do)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		do = c.middleware[i](do)
+	}
+
+	return do
+}
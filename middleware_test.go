@@ -0,0 +1,86 @@
+package firmafon
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClient_Use(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/employees/1", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "X-Trace", "abc")
+		fmt.Fprint(w, `{"employee":{"id": 1}}`)
+	})
+
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next DoFunc) DoFunc {
+			return func(req *http.Request, v interface{}) (*Response, error) {
+				op, ok := OperationFromContext(req.Context())
+				if !ok {
+					t.Errorf("%s: request has no operation", name)
+				}
+				calls = append(calls, name+" before "+op.String())
+				req.Header.Set("X-Trace", "abc")
+				resp, err := next(req, v)
+				emp := v.(**firmafonEmployee)
+				calls = append(calls, fmt.Sprintf("%s after %d %d %v", name, resp.StatusCode, (*emp).Employee.ID, err))
+				return resp, err
+			}
+		}
+	}
+	client.Use(record("outer"), record("inner"))
+
+	if _, _, err := client.Employees.GetById(1); err != nil {
+		t.Fatalf("GetById returned error: %v", err)
+	}
+
+	want := []string{
+		"outer before Employees.GetById",
+		"inner before Employees.GetById",
+		"inner after 200 1 <nil>",
+		"outer after 200 1 <nil>",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("middleware calls = %v, want %v", calls, want)
+	}
+}
+
+func TestClient_Use_ShortCircuit(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/calls", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request was sent despite the middleware returning early")
+	})
+
+	wantErr := errors.New("blocked")
+	client.Use(func(next DoFunc) DoFunc {
+		return func(req *http.Request, v interface{}) (*Response, error) {
+			return nil, wantErr
+		}
+	})
+
+	if _, _, err := client.Calls.GetAll(nil); err != wantErr {
+		t.Errorf("GetAll returned error %v, want %v", err, wantErr)
+	}
+}
+
+func TestOperationFromContext(t *testing.T) {
+	client := NewClient("")
+	req, _ := client.NewRequest("GET", ".", nil)
+	if _, ok := OperationFromContext(req.Context()); ok {
+		t.Error("OperationFromContext found an operation on a plain request")
+	}
+
+	req = withOperation(req, "Calls", "Get")
+	op, ok := OperationFromContext(req.Context())
+	if want := (Operation{Service: "Calls", Method: "Get"}); !ok || op != want {
+		t.Errorf("OperationFromContext = %v, %v, want %v, true", op, ok, want)
+	}
+}
@@ -0,0 +1,77 @@
+package firmafon
+
+import "fmt"
+
+type NumbersService service
+
+type firmafonNumbers struct {
+	Numbers []*CompanyNumber `json:"numbers"`
+}
+
+type firmafonNumber struct {
+	Number *CompanyNumber `json:"number"`
+}
+
+type firmafonNumberRouting struct {
+	Number struct {
+		Endpoint Endpoint `json:"endpoint"`
+	} `json:"number"`
+}
+
+// List returns the phone numbers of the company and where each is routed.
+func (s *NumbersService) List() ([]*CompanyNumber, *Response, error) {
+	req, err := s.client.NewRequest("GET", "numbers", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "Numbers", "List")
+
+	numbers := new(firmafonNumbers)
+	resp, err := s.client.Do(req, &numbers)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return numbers.Numbers, resp, nil
+}
+
+// Get returns a phone number by ID.
+func (s *NumbersService) Get(id int) (*CompanyNumber, *Response, error) {
+	url := fmt.Sprintf("numbers/%d", id)
+	req, err := s.client.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "Numbers", "Get")
+
+	number := new(firmafonNumber)
+	resp, err := s.client.Do(req, &number)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return number.Number, resp, nil
+}
+
+// SetRouting routes incoming calls to the number with the given ID to
+// endpoint, e.g. ReceptionEndpoint(1) or EmployeeGroupEndpoint(3). Only
+// administrators can change routing.
+func (s *NumbersService) SetRouting(id int, endpoint Endpoint) (*CompanyNumber, *Response, error) {
+	url := fmt.Sprintf("numbers/%d", id)
+	body := new(firmafonNumberRouting)
+	body.Number.Endpoint = endpoint
+
+	req, err := s.client.NewRequest("PUT", url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "Numbers", "SetRouting")
+
+	number := new(firmafonNumber)
+	resp, err := s.client.Do(req, &number)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return number.Number, resp, nil
+}
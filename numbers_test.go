@@ -0,0 +1,101 @@
+package firmafon
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNumbersService_List(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/numbers", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", mediaTypeJSON)
+		fmt.Fprint(w, `{
+		  "numbers": [
+			{"id": 1, "number": "4571999999", "name": "Hovednummer", "primary": true, "endpoint": "Reception#1"},
+			{"id": 2, "number": "4571999998", "name": "Support", "primary": false, "endpoint": "EmployeeGroup#3"},
+			{"id": 3, "number": "4571999997", "name": "Menu", "primary": false, "endpoint": "Ivr#2"},
+			{"id": 4, "number": "4571999996", "name": "Direkte", "primary": false, "endpoint": "Employee#7"}
+		  ]
+		}`)
+	})
+
+	numbers, _, err := client.Numbers.List()
+	if err != nil {
+		t.Errorf("Numbers.List returned error: %v", err)
+	}
+
+	want := []*CompanyNumber{
+		{ID: 1, Number: "4571999999", Name: "Hovednummer", Primary: true, Endpoint: ReceptionEndpoint(1)},
+		{ID: 2, Number: "4571999998", Name: "Support", Endpoint: EmployeeGroupEndpoint(3)},
+		{ID: 3, Number: "4571999997", Name: "Menu", Endpoint: IVREndpoint(2)},
+		{ID: 4, Number: "4571999996", Name: "Direkte", Endpoint: EmployeeEndpoint(7)},
+	}
+	if !reflect.DeepEqual(numbers, want) {
+		t.Errorf("Numbers.List returned %+v, want %+v", numbers, want)
+	}
+}
+
+func TestNumbersService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/numbers/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"number": {"id": 1, "number": "4571999999", "name": "Hovednummer", "endpoint": "Reception#1"}}`)
+	})
+
+	number, _, err := client.Numbers.Get(1)
+	if err != nil {
+		t.Errorf("Numbers.Get returned error: %v", err)
+	}
+
+	want := &CompanyNumber{ID: 1, Number: "4571999999", Name: "Hovednummer", Endpoint: ReceptionEndpoint(1)}
+	if !reflect.DeepEqual(number, want) {
+		t.Errorf("Numbers.Get returned %+v, want %+v", number, want)
+	}
+}
+
+func TestNumbersService_SetRouting(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/numbers/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testHeader(t, r, "Content-Type", mediaTypeJSON)
+		body, _ := io.ReadAll(r.Body)
+		if got, want := strings.TrimSpace(string(body)), `{"number":{"endpoint":"EmployeeGroup#3"}}`; got != want {
+			t.Errorf("request body = %s, want %s", got, want)
+		}
+		fmt.Fprint(w, `{"number": {"id": 1, "number": "4571999999", "endpoint": "EmployeeGroup#3"}}`)
+	})
+
+	number, _, err := client.Numbers.SetRouting(1, EmployeeGroupEndpoint(3))
+	if err != nil {
+		t.Errorf("Numbers.SetRouting returned error: %v", err)
+	}
+
+	want := &CompanyNumber{ID: 1, Number: "4571999999", Endpoint: EmployeeGroupEndpoint(3)}
+	if !reflect.DeepEqual(number, want) {
+		t.Errorf("Numbers.SetRouting returned %+v, want %+v", number, want)
+	}
+}
+
+func TestNumbersService_SetRouting_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/numbers/1", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	})
+
+	if _, _, err := client.Numbers.SetRouting(1, ReceptionEndpoint(1)); err == nil {
+		t.Error("Numbers.SetRouting expected an error but got none")
+	}
+}
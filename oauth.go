@@ -0,0 +1,84 @@
+package firmafon
+
+import (
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// OAuth2Endpoint is the OAuth2 endpoint of Firmafon.
+var OAuth2Endpoint = oauth2.Endpoint{
+	AuthURL:   "https://app.firmafon.dk/oauth/authorize",
+	TokenURL:  "https://app.firmafon.dk/oauth/token",
+	AuthStyle: oauth2.AuthStyleInParams,
+}
+
+// NewOAuth2Config returns the configuration for the OAuth2 authorization code
+// flow of an application registered with Firmafon.
+//
+//	conf := firmafon.NewOAuth2Config(clientID, clientSecret, "https://example.com/callback")
+//
+//	// redirect the user to the consent page
+//	url := conf.AuthCodeURL(state)
+//
+//	// exchange the code Firmafon sends to the redirect URL for a token
+//	tok, err := conf.Exchange(ctx, code)
+//
+//	client := firmafon.NewClientFromTokenSource(conf.TokenSource(ctx, tok))
+func NewOAuth2Config(clientID, clientSecret, redirectURL string, scopes ...string) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       scopes,
+		Endpoint:     OAuth2Endpoint,
+	}
+}
+
+// NewClientFromTokenSource returns a new client that authenticates with
+// tokens from ts instead of a static access token. The token is fetched for
+// every request, so a refreshing token source such as the one returned by
+// oauth2.Config.TokenSource rotates tokens without restarting the client.
+func NewClientFromTokenSource(ts oauth2.TokenSource) *Client {
+	c := NewClient("")
+	c.tokens = tokenSourceProvider{oauth2.ReuseTokenSource(nil, ts)}
+
+	return c
+}
+
+// TokenNotifyFunc is called with a token whenever it changes.
+type TokenNotifyFunc func(*oauth2.Token) error
+
+// NotifyingTokenSource returns a token source that calls fn whenever ts
+// returns a new token, e.g. to persist a refreshed refresh token. An error
+// from fn is returned from Token.
+func NotifyingTokenSource(ts oauth2.TokenSource, fn TokenNotifyFunc) oauth2.TokenSource {
+	return &notifyingTokenSource{src: ts, fn: fn}
+}
+
+type notifyingTokenSource struct {
+	src oauth2.TokenSource
+	fn  TokenNotifyFunc
+
+	mu   sync.Mutex
+	last *oauth2.Token
+}
+
+func (s *notifyingTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tok, err := s.src.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.last == nil || s.last.AccessToken != tok.AccessToken || s.last.RefreshToken != tok.RefreshToken {
+		if err := s.fn(tok); err != nil {
+			return nil, err
+		}
+		s.last = tok
+	}
+
+	return tok, nil
+}
@@ -0,0 +1,121 @@
+package firmafon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestNewOAuth2Config(t *testing.T) {
+	conf := NewOAuth2Config("id", "secret", "https://example.com/callback")
+
+	u, err := url.Parse(conf.AuthCodeURL("state"))
+	if err != nil {
+		t.Fatalf("AuthCodeURL returned invalid URL: %v", err)
+	}
+	if got, want := u.Scheme+"://"+u.Host+u.Path, OAuth2Endpoint.AuthURL; got != want {
+		t.Errorf("AuthCodeURL = %v, want %v", got, want)
+	}
+	q := u.Query()
+	for k, want := range map[string]string{
+		"client_id":     "id",
+		"redirect_uri":  "https://example.com/callback",
+		"response_type": "code",
+		"state":         "state",
+	} {
+		if got := q.Get(k); got != want {
+			t.Errorf("AuthCodeURL %s = %q, want %q", k, got, want)
+		}
+	}
+}
+
+func TestNewClientFromTokenSource(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var grants []string
+	tokens := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		mu.Lock()
+		defer mu.Unlock()
+		grants = append(grants, r.Form.Get("grant_type")+":"+r.Form.Get("code")+r.Form.Get("refresh_token"))
+
+		// tokens expire immediately so every request refreshes
+		w.Header().Set("Content-Type", mediaTypeJSON)
+		fmt.Fprintf(w, `{"access_token":"access%d","refresh_token":"refresh%d","token_type":"bearer","expires_in":1}`,
+			len(grants), len(grants))
+	}))
+	defer tokens.Close()
+
+	var auths []string
+	mux.HandleFunc("/employee", func(w http.ResponseWriter, r *http.Request) {
+		auths = append(auths, r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"employee":{"id":1}}`)
+	})
+
+	conf := NewOAuth2Config("id", "secret", "https://example.com/callback")
+	conf.Endpoint.TokenURL = tokens.URL
+
+	ctx := context.Background()
+	tok, err := conf.Exchange(ctx, "code")
+	if err != nil {
+		t.Fatalf("Exchange returned error: %v", err)
+	}
+
+	var saved []string
+	ts := NotifyingTokenSource(conf.TokenSource(ctx, tok), func(tok *oauth2.Token) error {
+		saved = append(saved, tok.RefreshToken)
+		return nil
+	})
+
+	c := NewClientFromTokenSource(ts)
+	c.BaseURL = client.BaseURL
+	for i := 0; i < 2; i++ {
+		if _, _, err := c.Employees.Authenticated(); err != nil {
+			t.Fatalf("Authenticated returned error: %v", err)
+		}
+	}
+
+	if want := []string{"authorization_code:code", "refresh_token:refresh1", "refresh_token:refresh2"}; !reflect.DeepEqual(grants, want) {
+		t.Errorf("token requests = %v, want %v", grants, want)
+	}
+	if want := []string{"Bearer access2", "Bearer access3"}; !reflect.DeepEqual(auths, want) {
+		t.Errorf("Authorization headers = %v, want %v", auths, want)
+	}
+	if want := []string{"refresh2", "refresh3"}; !reflect.DeepEqual(saved, want) {
+		t.Errorf("saved refresh tokens = %v, want %v", saved, want)
+	}
+}
+
+type errTokenSource struct{ err error }
+
+func (s errTokenSource) Token() (*oauth2.Token, error) { return nil, s.err }
+
+func TestNewClientFromTokenSource_error(t *testing.T) {
+	want := errors.New("token revoked")
+	c := NewClientFromTokenSource(errTokenSource{want})
+
+	if _, err := c.NewRequest("GET", "employee", nil); !errors.Is(err, want) {
+		t.Errorf("NewRequest returned %v, want %v", err, want)
+	}
+}
+
+func TestNotifyingTokenSource_error(t *testing.T) {
+	want := errors.New("disk full")
+	ts := NotifyingTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "a"}), func(*oauth2.Token) error {
+		return want
+	})
+
+	if _, err := ts.Token(); !errors.Is(err, want) {
+		t.Errorf("Token returned %v, want %v", err, want)
+	}
+}
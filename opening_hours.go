@@ -0,0 +1,169 @@
+package firmafon
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultTimeZone is the time zone of opening hours that don't specify one.
+const DefaultTimeZone = "Europe/Copenhagen"
+
+type OpeningHoursService service
+
+// OpeningHours is the weekly schedule of the company, used to route calls
+// differently outside opening hours, with exceptions for holidays and other
+// special days.
+type OpeningHours struct {
+	// TimeZone is the IANA time zone of the schedule, e.g.
+	// "Europe/Copenhagen". If empty, DefaultTimeZone is used.
+	TimeZone string `json:"time_zone,omitempty"`
+
+	// Weekly holds the periods the company is open each week. A day may
+	// have more than one period, e.g. to close for lunch.
+	Weekly []*OpeningPeriod `json:"weekly"`
+
+	// ClosedOnPublicHolidays keeps the company closed on Danish public
+	// holidays, see DanishPublicHolidays.
+	ClosedOnPublicHolidays bool `json:"closed_on_public_holidays"`
+
+	// Exceptions replace the weekly schedule on single dates.
+	Exceptions []*OpeningException `json:"exceptions"`
+}
+
+// OpeningPeriod is a period of a weekday the company is open.
+type OpeningPeriod struct {
+	Weekday time.Weekday `json:"weekday"`
+	Open    ClockTime    `json:"open"`
+	Close   ClockTime    `json:"close"`
+}
+
+// OpeningException replaces the weekly schedule on a date, e.g. to close on
+// Christmas Eve or open late for an event. Public holidays are ignored on a
+// date with an exception.
+type OpeningException struct {
+	Date Date   `json:"date"`
+	Name string `json:"name,omitempty"`
+
+	// Closed keeps the company closed all day. Otherwise it is open from
+	// Open to Close.
+	Closed bool      `json:"closed"`
+	Open   ClockTime `json:"open,omitempty"`
+	Close  ClockTime `json:"close,omitempty"`
+}
+
+// ClockTime is a time of day in minutes after midnight. It is encoded as
+// "15:04", and "24:00" is the end of the day.
+type ClockTime int
+
+// NewClockTime returns the ClockTime hour:min.
+func NewClockTime(hour, min int) ClockTime {
+	return ClockTime(hour*60 + min)
+}
+
+// ClockTimeOf returns the time of day of t in its location.
+func ClockTimeOf(t time.Time) ClockTime {
+	return NewClockTime(t.Hour(), t.Minute())
+}
+
+func (c ClockTime) String() string {
+	return fmt.Sprintf("%02d:%02d", int(c)/60, int(c)%60)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c ClockTime) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *ClockTime) UnmarshalText(text []byte) error {
+	var hour, min int
+	if n, err := fmt.Sscanf(string(text), "%d:%d", &hour, &min); n != 2 || err != nil ||
+		len(text) != 5 || hour < 0 || min < 0 || min > 59 || hour > 24 || (hour == 24 && min != 0) {
+		return fmt.Errorf("firmafon: invalid time of day %q", text)
+	}
+	*c = NewClockTime(hour, min)
+
+	return nil
+}
+
+// Date is a calendar date without a time zone, encoded as "2006-01-02".
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date of t in its location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// In returns the start of the date in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// AddDays returns the date n days after d.
+func (d Date) AddDays(n int) Date {
+	return DateOf(time.Date(d.Year, d.Month, d.Day+n, 0, 0, 0, 0, time.UTC))
+}
+
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Date) UnmarshalText(text []byte) error {
+	t, err := time.Parse("2006-01-02", string(text))
+	if err != nil {
+		return fmt.Errorf("firmafon: invalid date %q", text)
+	}
+	*d = DateOf(t)
+
+	return nil
+}
+
+type firmafonOpeningHours struct {
+	OpeningHours *OpeningHours `json:"opening_hours"`
+}
+
+// Get returns the opening hours of the company.
+func (s *OpeningHoursService) Get() (*OpeningHours, *Response, error) {
+	req, err := s.client.NewRequest("GET", "opening_hours", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "OpeningHours", "Get")
+
+	h := new(firmafonOpeningHours)
+	resp, err := s.client.Do(req, &h)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return h.OpeningHours, resp, nil
+}
+
+// Update replaces the opening hours of the company, including the
+// exceptions. Only administrators can update the opening hours.
+func (s *OpeningHoursService) Update(h *OpeningHours) (*OpeningHours, *Response, error) {
+	req, err := s.client.NewRequest("PUT", "opening_hours", firmafonOpeningHours{h})
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "OpeningHours", "Update")
+
+	updated := new(firmafonOpeningHours)
+	resp, err := s.client.Do(req, &updated)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return updated.OpeningHours, resp, nil
+}
@@ -0,0 +1,79 @@
+package firmafon
+
+import (
+	"errors"
+	"time"
+)
+
+// An OpeningHoursEvaluator answers whether the company is open at a given
+// time without calling the API. Get the opening hours with
+// OpeningHoursService.Get once and reuse the evaluator.
+type OpeningHoursEvaluator struct {
+	loc        *time.Location
+	weekly     map[time.Weekday][]*OpeningPeriod
+	exceptions map[Date]*OpeningException
+	holidays   bool
+}
+
+// NewOpeningHoursEvaluator returns an evaluator for h. It loads the time
+// zone of h, so programs running without a time zone database should import
+// time/tzdata.
+func NewOpeningHoursEvaluator(h *OpeningHours) (*OpeningHoursEvaluator, error) {
+	if h == nil {
+		return nil, errors.New("firmafon: opening hours are nil")
+	}
+
+	tz := h.TimeZone
+	if tz == "" {
+		tz = DefaultTimeZone
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &OpeningHoursEvaluator{
+		loc:        loc,
+		weekly:     make(map[time.Weekday][]*OpeningPeriod),
+		exceptions: make(map[Date]*OpeningException, len(h.Exceptions)),
+		holidays:   h.ClosedOnPublicHolidays,
+	}
+	for _, p := range h.Weekly {
+		e.weekly[p.Weekday] = append(e.weekly[p.Weekday], p)
+	}
+	for _, x := range h.Exceptions {
+		e.exceptions[x.Date] = x
+	}
+
+	return e, nil
+}
+
+// Location returns the time zone the opening hours are evaluated in.
+func (e *OpeningHoursEvaluator) Location() *time.Location {
+	return e.loc
+}
+
+// IsOpen reports whether the company is open at t. An exception for the
+// date of t takes precedence over public holidays, which take precedence
+// over the weekly schedule. Periods include their opening time and exclude
+// their closing time.
+func (e *OpeningHoursEvaluator) IsOpen(t time.Time) bool {
+	t = t.In(e.loc)
+	date, clock := DateOf(t), ClockTimeOf(t)
+
+	if x, ok := e.exceptions[date]; ok {
+		return !x.Closed && clock >= x.Open && clock < x.Close
+	}
+	if e.holidays {
+		if _, ok := DanishPublicHoliday(date); ok {
+			return false
+		}
+	}
+	for _, p := range e.weekly[t.Weekday()] {
+		if clock >= p.Open && clock < p.Close {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,71 @@
+package firmafon
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOpeningHoursEvaluator_IsOpen(t *testing.T) {
+	h := &OpeningHours{
+		Weekly: []*OpeningPeriod{
+			{Weekday: time.Monday, Open: NewClockTime(8, 0), Close: NewClockTime(12, 0)},
+			{Weekday: time.Monday, Open: NewClockTime(12, 30), Close: NewClockTime(16, 0)},
+			{Weekday: time.Thursday, Open: NewClockTime(8, 0), Close: NewClockTime(16, 0)},
+			{Weekday: time.Sunday, Open: NewClockTime(0, 0), Close: NewClockTime(24, 0)},
+		},
+		ClosedOnPublicHolidays: true,
+		Exceptions: []*OpeningException{
+			{Date: Date{2025, time.April, 21}, Name: "2. påskedag", Open: NewClockTime(10, 0), Close: NewClockTime(14, 0)},
+			{Date: Date{2025, time.June, 5}, Name: "Grundlovsdag", Closed: true},
+		},
+	}
+
+	e, err := NewOpeningHoursEvaluator(h)
+	if err != nil {
+		t.Fatalf("NewOpeningHoursEvaluator returned error: %v", err)
+	}
+	if got := e.Location().String(); got != DefaultTimeZone {
+		t.Errorf("Location returned %q, want %q", got, DefaultTimeZone)
+	}
+
+	cph := e.Location()
+	tests := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{"monday morning", time.Date(2025, time.June, 2, 8, 0, 0, 0, cph), true},
+		{"monday lunch", time.Date(2025, time.June, 2, 12, 15, 0, 0, cph), false},
+		{"monday closing time", time.Date(2025, time.June, 2, 16, 0, 0, 0, cph), false},
+		{"monday in UTC", time.Date(2025, time.June, 2, 6, 30, 0, 0, time.UTC), true},
+		{"monday before opening in UTC", time.Date(2025, time.June, 2, 5, 59, 0, 0, time.UTC), false},
+		{"tuesday", time.Date(2025, time.June, 3, 10, 0, 0, 0, cph), false},
+		{"closed exception", time.Date(2025, time.June, 5, 10, 0, 0, 0, cph), false},
+		{"holiday", time.Date(2025, time.April, 17, 10, 0, 0, 0, cph), false},
+		{"exception on holiday", time.Date(2025, time.April, 21, 11, 0, 0, 0, cph), true},
+		{"exception on holiday after hours", time.Date(2025, time.April, 21, 15, 0, 0, 0, cph), false},
+		{"sunday until midnight", time.Date(2025, time.June, 15, 23, 59, 0, 0, cph), true},
+		{"sunday winter time", time.Date(2025, time.January, 5, 23, 30, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if got := e.IsOpen(tt.t); got != tt.want {
+			t.Errorf("IsOpen(%s) returned %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// holidays follow the weekly schedule unless ClosedOnPublicHolidays is set
+	h.ClosedOnPublicHolidays = false
+	e, _ = NewOpeningHoursEvaluator(h)
+	if !e.IsOpen(time.Date(2025, time.April, 17, 10, 0, 0, 0, cph)) {
+		t.Errorf("IsOpen on a holiday returned false, want true")
+	}
+}
+
+func TestNewOpeningHoursEvaluator_invalid(t *testing.T) {
+	if _, err := NewOpeningHoursEvaluator(nil); err == nil {
+		t.Error("NewOpeningHoursEvaluator(nil) expected an error but got none")
+	}
+	if _, err := NewOpeningHoursEvaluator(&OpeningHours{TimeZone: "Europe/Nowhere"}); err == nil {
+		t.Error("NewOpeningHoursEvaluator expected an error for an unknown time zone but got none")
+	}
+}
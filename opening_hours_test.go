@@ -0,0 +1,146 @@
+package firmafon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOpeningHoursService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/opening_hours", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", mediaTypeJSON)
+		fmt.Fprint(w, `{
+		  "opening_hours": {
+			"time_zone": "Europe/Copenhagen",
+			"weekly": [
+			  {"weekday": 1, "open": "08:00", "close": "12:00"},
+			  {"weekday": 1, "open": "12:30", "close": "16:00"}
+			],
+			"closed_on_public_holidays": true,
+			"exceptions": [
+			  {"date": "2024-12-24", "name": "Juleaften", "closed": true},
+			  {"date": "2024-06-05", "name": "Grundlovsdag", "closed": false, "open": "08:00", "close": "12:00"}
+			]
+		  }
+		}`)
+	})
+
+	h, _, err := client.OpeningHours.Get()
+	if err != nil {
+		t.Errorf("OpeningHours.Get returned error: %v", err)
+	}
+
+	want := &OpeningHours{
+		TimeZone: "Europe/Copenhagen",
+		Weekly: []*OpeningPeriod{
+			{Weekday: time.Monday, Open: NewClockTime(8, 0), Close: NewClockTime(12, 0)},
+			{Weekday: time.Monday, Open: NewClockTime(12, 30), Close: NewClockTime(16, 0)},
+		},
+		ClosedOnPublicHolidays: true,
+		Exceptions: []*OpeningException{
+			{Date: Date{2024, time.December, 24}, Name: "Juleaften", Closed: true},
+			{Date: Date{2024, time.June, 5}, Name: "Grundlovsdag", Open: NewClockTime(8, 0), Close: NewClockTime(12, 0)},
+		},
+	}
+	if !reflect.DeepEqual(h, want) {
+		t.Errorf("OpeningHours.Get returned %+v, want %+v", h, want)
+	}
+}
+
+func TestOpeningHoursService_Update(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	input := &OpeningHours{
+		Weekly: []*OpeningPeriod{{Weekday: time.Friday, Open: NewClockTime(9, 0), Close: NewClockTime(15, 30)}},
+		Exceptions: []*OpeningException{
+			{Date: Date{2024, time.December, 31}, Closed: true},
+		},
+	}
+
+	mux.HandleFunc("/opening_hours", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		v := new(firmafonOpeningHours)
+		json.NewDecoder(r.Body).Decode(v)
+		if !reflect.DeepEqual(v.OpeningHours, input) {
+			t.Errorf("Request body = %+v, want %+v", v.OpeningHours, input)
+		}
+		json.NewEncoder(w).Encode(v)
+	})
+
+	h, _, err := client.OpeningHours.Update(input)
+	if err != nil {
+		t.Errorf("OpeningHours.Update returned error: %v", err)
+	}
+	if !reflect.DeepEqual(h, input) {
+		t.Errorf("OpeningHours.Update returned %+v, want %+v", h, input)
+	}
+}
+
+func TestOpeningHoursService_Get_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/opening_hours", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	})
+
+	if _, _, err := client.OpeningHours.Get(); err == nil {
+		t.Error("OpeningHours.Get expected an error but got none")
+	}
+}
+
+func TestClockTime_text(t *testing.T) {
+	valid := map[string]ClockTime{
+		"00:00": 0,
+		"08:30": NewClockTime(8, 30),
+		"23:59": NewClockTime(23, 59),
+		"24:00": NewClockTime(24, 0),
+	}
+	for in, want := range valid {
+		var c ClockTime
+		if err := c.UnmarshalText([]byte(in)); err != nil {
+			t.Errorf("UnmarshalText(%q) returned error: %v", in, err)
+		}
+		if c != want {
+			t.Errorf("UnmarshalText(%q) returned %v, want %v", in, c, want)
+		}
+		if got := c.String(); got != in {
+			t.Errorf("String returned %q, want %q", got, in)
+		}
+	}
+
+	for _, in := range []string{"", "8:30", "08:60", "24:01", "25:00", "-1:00", "08.30", "08:30:00"} {
+		var c ClockTime
+		if err := c.UnmarshalText([]byte(in)); err == nil {
+			t.Errorf("UnmarshalText(%q) expected an error but got none", in)
+		}
+	}
+}
+
+func TestDate_text(t *testing.T) {
+	var d Date
+	if err := d.UnmarshalText([]byte("2024-02-29")); err != nil {
+		t.Fatalf("UnmarshalText returned error: %v", err)
+	}
+	if want := (Date{2024, time.February, 29}); d != want {
+		t.Errorf("UnmarshalText returned %v, want %v", d, want)
+	}
+	if got := d.String(); got != "2024-02-29" {
+		t.Errorf("String returned %q, want %q", got, "2024-02-29")
+	}
+	if got, want := d.AddDays(1), (Date{2024, time.March, 1}); got != want {
+		t.Errorf("AddDays(1) returned %v, want %v", got, want)
+	}
+
+	if err := d.UnmarshalText([]byte("2023-02-29")); err == nil {
+		t.Error("UnmarshalText expected an error but got none")
+	}
+}
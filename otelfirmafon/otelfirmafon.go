@@ -0,0 +1,155 @@
+// Package otelfirmafon instruments the Firmafon client with OpenTelemetry.
+//
+// Add the middleware to a client and make requests through a client carrying
+// the caller's context so the spans are children of the caller's span:
+//
+//	client := firmafon.NewClient("token")
+//	client.Use(otelfirmafon.Middleware())
+//
+//	emps, _, err := client.WithContext(ctx).Employees.All()
+package otelfirmafon
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	firmafon "github.com/steffen25/go-firmafon"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/steffen25/go-firmafon/otelfirmafon"
+
+// OperationKey is the attribute holding the service method that made a
+// request, e.g. "Employees.GetById".
+const OperationKey = attribute.Key("firmafon.operation")
+
+// Metric names recorded by the middleware.
+const (
+	RequestCountMetric    = "firmafon.client.requests"
+	RequestDurationMetric = "firmafon.client.duration"
+	RequestErrorsMetric   = "firmafon.client.errors"
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// Option configures the middleware.
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider. Defaults to the global provider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) { c.tracerProvider = tp }
+}
+
+// WithMeterProvider sets the meter provider. Defaults to the global provider.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) { c.meterProvider = mp }
+}
+
+// WithPropagators sets the propagators used to inject the trace context into
+// requests. Defaults to the global propagators.
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *config) { c.propagators = p }
+}
+
+// Middleware returns middleware that creates a client span per request,
+// records request count, latency and error metrics and propagates the trace
+// context to the API.
+func Middleware(opts ...Option) firmafon.Middleware {
+	cfg := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	tracer := cfg.tracerProvider.Tracer(instrumentationName)
+	meter := cfg.meterProvider.Meter(instrumentationName)
+
+	// instrument creation only fails for invalid names, so the errors are
+	// ignored and the no-op instruments returned alongside them are used
+	requests, _ := meter.Int64Counter(RequestCountMetric,
+		metric.WithDescription("Number of requests sent to the Firmafon API."),
+		metric.WithUnit("{request}"))
+	duration, _ := meter.Float64Histogram(RequestDurationMetric,
+		metric.WithDescription("Duration of requests sent to the Firmafon API."),
+		metric.WithUnit("s"))
+	failures, _ := meter.Int64Counter(RequestErrorsMetric,
+		metric.WithDescription("Number of requests to the Firmafon API that failed."),
+		metric.WithUnit("{request}"))
+
+	return func(next firmafon.DoFunc) firmafon.DoFunc {
+		return func(req *http.Request, v interface{}) (*firmafon.Response, error) {
+			operation := "unknown"
+			if op, ok := firmafon.OperationFromContext(req.Context()); ok {
+				operation = op.String()
+			}
+
+			attrs := []attribute.KeyValue{
+				OperationKey.String(operation),
+				semconv.HTTPRequestMethodKey.String(req.Method),
+				semconv.URLFull(firmafon.RedactURL(req.URL).String()),
+				semconv.ServerAddress(req.URL.Hostname()),
+			}
+			if port, err := strconv.Atoi(req.URL.Port()); err == nil {
+				attrs = append(attrs, semconv.ServerPort(port))
+			}
+
+			ctx, span := tracer.Start(req.Context(), operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...))
+			defer span.End()
+
+			req = req.WithContext(ctx)
+			cfg.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			start := time.Now()
+			resp, err := next(req, v)
+			elapsed := time.Since(start).Seconds()
+
+			metricAttrs := []attribute.KeyValue{
+				OperationKey.String(operation),
+				semconv.HTTPRequestMethodKey.String(req.Method),
+			}
+			status := 0
+			if resp != nil && resp.Response != nil {
+				status = resp.StatusCode
+				span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+				metricAttrs = append(metricAttrs, semconv.HTTPResponseStatusCode(status))
+			}
+
+			if err != nil || status >= 400 {
+				errorType := strconv.Itoa(status)
+				if err != nil && status < 400 {
+					errorType = "client"
+				}
+				span.SetAttributes(semconv.ErrorTypeKey.String(errorType))
+				if err != nil {
+					span.RecordError(err)
+					span.SetStatus(codes.Error, err.Error())
+				} else {
+					span.SetStatus(codes.Error, http.StatusText(status))
+				}
+				metricAttrs = append(metricAttrs, semconv.ErrorTypeKey.String(errorType))
+				failures.Add(ctx, 1, metric.WithAttributes(metricAttrs...))
+			}
+
+			requests.Add(ctx, 1, metric.WithAttributes(metricAttrs...))
+			duration.Record(ctx, elapsed, metric.WithAttributes(metricAttrs...))
+
+			return resp, err
+		}
+	}
+}
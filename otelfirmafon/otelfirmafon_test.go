@@ -0,0 +1,178 @@
+package otelfirmafon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	firmafon "github.com/steffen25/go-firmafon"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setup(t *testing.T, handler http.HandlerFunc) (*firmafon.Client, *tracetest.InMemoryExporter, *sdkmetric.ManualReader, *sdktrace.TracerProvider) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	client := firmafon.NewClient("secret")
+	client.BaseURL, _ = url.Parse(server.URL + "/api/v2/")
+	client.Use(Middleware(
+		WithTracerProvider(tp),
+		WithMeterProvider(mp),
+		WithPropagators(propagation.TraceContext{}),
+	))
+
+	return client, exporter, reader, tp
+}
+
+func spanAttr(span tracetest.SpanStub, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestMiddleware_Span(t *testing.T) {
+	var traceparent string
+	client, exporter, _, tp := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		fmt.Fprint(w, `{"employee":{"id": 1}}`)
+	})
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	if _, _, err := client.WithContext(ctx).Employees.GetById(1); err != nil {
+		t.Fatalf("GetById returned error: %v", err)
+	}
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	span := spans[0]
+
+	if got, want := span.Name, "Employees.GetById"; got != want {
+		t.Errorf("span name = %q, want %q", got, want)
+	}
+	if got, want := span.SpanKind, trace.SpanKindClient; got != want {
+		t.Errorf("span kind = %v, want %v", got, want)
+	}
+	if got, want := span.Parent.SpanID(), parent.SpanContext().SpanID(); got != want {
+		t.Errorf("parent span = %v, want %v", got, want)
+	}
+
+	wantAttrs := map[attribute.Key]attribute.Value{
+		OperationKey:                attribute.StringValue("Employees.GetById"),
+		"http.request.method":       attribute.StringValue("GET"),
+		"http.response.status_code": attribute.IntValue(200),
+		"server.address":            attribute.StringValue("127.0.0.1"),
+		"url.full":                  attribute.StringValue(client.BaseURL.String() + "employees/1"),
+	}
+	for k, want := range wantAttrs {
+		if got, ok := spanAttr(span, k); !ok || got != want {
+			t.Errorf("attribute %s = %v, want %v", k, got.Emit(), want.Emit())
+		}
+	}
+
+	want := fmt.Sprintf("00-%s-%s-01", span.SpanContext.TraceID(), span.SpanContext.SpanID())
+	if traceparent != want {
+		t.Errorf("traceparent header = %q, want %q", traceparent, want)
+	}
+}
+
+func TestMiddleware_Error(t *testing.T) {
+	client, exporter, reader, _ := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	})
+
+	if _, _, err := client.Calls.GetAll(nil); err == nil {
+		t.Fatal("GetAll expected an error but got none")
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if got := spans[0].Status.Code; got != codes.Error {
+		t.Errorf("span status = %v, want %v", got, codes.Error)
+	}
+	if got, ok := spanAttr(spans[0], "error.type"); !ok || got.AsString() != "500" {
+		t.Errorf("error.type = %v, want 500", got.Emit())
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+
+	found := map[string]bool{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			found[m.Name] = true
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				if len(data.DataPoints) != 1 || data.DataPoints[0].Value != 1 {
+					t.Errorf("%s data points = %+v, want a single point with value 1", m.Name, data.DataPoints)
+				}
+				if v, ok := data.DataPoints[0].Attributes.Value(OperationKey); !ok || v.AsString() != "Calls.GetAll" {
+					t.Errorf("%s operation = %v, want Calls.GetAll", m.Name, v.Emit())
+				}
+			case metricdata.Histogram[float64]:
+				if len(data.DataPoints) != 1 || data.DataPoints[0].Count != 1 {
+					t.Errorf("%s data points = %+v, want a single point with count 1", m.Name, data.DataPoints)
+				}
+			}
+		}
+	}
+	for _, name := range []string{RequestCountMetric, RequestDurationMetric, RequestErrorsMetric} {
+		if !found[name] {
+			t.Errorf("metric %s was not recorded", name)
+		}
+	}
+}
+
+func TestMiddleware_SpanRedactsURL(t *testing.T) {
+	client, exporter, _, _ := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"calls":[]}`)
+	})
+
+	opt := &firmafon.CallsListOptions{Number: "4512345678", Limit: "1"}
+	if _, _, err := client.Calls.GetAll(opt); err != nil {
+		t.Fatalf("GetAll returned error: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	got, _ := spanAttr(spans[0], "url.full")
+	u, err := url.Parse(got.AsString())
+	if err != nil {
+		t.Fatalf("url.full %q is not a URL: %v", got.AsString(), err)
+	}
+	if strings.Contains(u.RawQuery, "4512345678") {
+		t.Errorf("url.full = %q, leaks the number filter", got.AsString())
+	}
+	if q := u.Query(); q.Get("number") != "REDACTED" || q.Get("limit") != "1" {
+		t.Errorf("url.full query = %v, want number=REDACTED and limit=1", q)
+	}
+}
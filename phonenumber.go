@@ -0,0 +1,125 @@
+package firmafon
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// DefaultCountryCode is assumed for numbers written without a country code.
+const DefaultCountryCode = "45"
+
+// ErrInvalidPhoneNumber is returned by ParsePhoneNumber when a number can't be
+// normalized.
+var ErrInvalidPhoneNumber = errors.New("firmafon: invalid phone number")
+
+// PhoneNumber is a phone number in any of the formats understood by
+// ParsePhoneNumber, e.g. "12345678", "4512345678", "+45 12 34 56 78" or
+// "0045-12345678". The API returns numbers without a leading plus, but
+// numbers from other systems rarely follow that format, so compare numbers
+// with Equal rather than ==.
+type PhoneNumber string
+
+// ParsePhoneNumber normalizes s to E.164, e.g. "+4512345678". Numbers without
+// a country code are assumed to be Danish.
+func ParsePhoneNumber(s string) (PhoneNumber, error) {
+	digits := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')', '/', '\t', '\u00a0':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(s))
+
+	international := false
+	switch {
+	case strings.HasPrefix(digits, "+"):
+		digits = digits[1:]
+		international = true
+	case strings.HasPrefix(digits, "00"):
+		digits = digits[2:]
+		international = true
+	}
+
+	if digits == "" {
+		return "", ErrInvalidPhoneNumber
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return "", ErrInvalidPhoneNumber
+		}
+	}
+
+	if !international {
+		switch {
+		case len(digits) == 8:
+			digits = DefaultCountryCode + digits
+		case len(digits) < 10:
+			return "", ErrInvalidPhoneNumber
+		}
+	}
+
+	// E.164 numbers are at most 15 digits and Danish numbers always have 8
+	// digits after the country code.
+	if len(digits) < 8 || len(digits) > 15 {
+		return "", ErrInvalidPhoneNumber
+	}
+	if strings.HasPrefix(digits, "45") && len(digits) != 10 {
+		return "", ErrInvalidPhoneNumber
+	}
+
+	return PhoneNumber("+" + digits), nil
+}
+
+// E164 returns n in E.164 format or "" if n is not a valid phone number.
+func (n PhoneNumber) E164() string {
+	p, err := ParsePhoneNumber(string(n))
+	if err != nil {
+		return ""
+	}
+
+	return string(p)
+}
+
+// Digits returns n in the format used by the API, which is E.164 without the
+// leading plus. Invalid numbers are returned as is.
+func (n PhoneNumber) Digits() string {
+	p := n.E164()
+	if p == "" {
+		return string(n)
+	}
+
+	return p[1:]
+}
+
+// Format returns n formatted for display. Danish numbers are grouped in
+// pairs, e.g. "+45 12 34 56 78". Invalid numbers are returned as is.
+func (n PhoneNumber) Format() string {
+	p := n.E164()
+	switch {
+	case p == "":
+		return string(n)
+	case strings.HasPrefix(p, "+"+DefaultCountryCode) && len(p) == 11:
+		return p[:3] + " " + p[3:5] + " " + p[5:7] + " " + p[7:9] + " " + p[9:]
+	}
+
+	return p
+}
+
+// Equal reports whether n and o are the same phone number regardless of
+// format. Invalid numbers are only equal if they are identical.
+func (n PhoneNumber) Equal(o PhoneNumber) bool {
+	a, b := n.E164(), o.E164()
+	if a == "" || b == "" {
+		return strings.TrimSpace(string(n)) == strings.TrimSpace(string(o))
+	}
+
+	return a == b
+}
+
+// EncodeValues implements query.Encoder so numbers in list options are sent
+// in the format the API expects.
+func (n PhoneNumber) EncodeValues(key string, v *url.Values) error {
+	v.Set(key, n.Digits())
+	return nil
+}
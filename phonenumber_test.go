@@ -0,0 +1,107 @@
+package firmafon
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestParsePhoneNumber(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{in: "12345678", want: "+4512345678"},
+		{in: "4512345678", want: "+4512345678"},
+		{in: "+45 12 34 56 78", want: "+4512345678"},
+		{in: "0045-1234 5678", want: "+4512345678"},
+		{in: " (+45) 12.34.56.78 ", want: "+4512345678"},
+		{in: "+46 8 123 456 78", want: "+46812345678"},
+		{in: "004915112345678", want: "+4915112345678"},
+		{in: "4915112345678", want: "+4915112345678"},
+		{in: "", wantErr: true},
+		{in: "anonymous", wantErr: true},
+		{in: "112", wantErr: true},
+		{in: "1234567", wantErr: true},
+		{in: "+451234567", wantErr: true},
+		{in: "+1234567890123456", wantErr: true},
+	}
+
+	for _, test := range tests {
+		got, err := ParsePhoneNumber(test.in)
+		if test.wantErr {
+			if err != ErrInvalidPhoneNumber {
+				t.Errorf("ParsePhoneNumber(%q) returned error %v, want %v", test.in, err, ErrInvalidPhoneNumber)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePhoneNumber(%q) returned error: %v", test.in, err)
+		}
+		if string(got) != test.want {
+			t.Errorf("ParsePhoneNumber(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestPhoneNumber_Format(t *testing.T) {
+	tests := []struct {
+		in   PhoneNumber
+		want string
+	}{
+		{"4512345678", "+45 12 34 56 78"},
+		{"+46812345678", "+46812345678"},
+		{"anonymous", "anonymous"},
+	}
+
+	for _, test := range tests {
+		if got := test.in.Format(); got != test.want {
+			t.Errorf("PhoneNumber(%q).Format() = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestPhoneNumber_Digits(t *testing.T) {
+	if got, want := PhoneNumber("+45 12 34 56 78").Digits(), "4512345678"; got != want {
+		t.Errorf("Digits() = %q, want %q", got, want)
+	}
+	if got, want := PhoneNumber("anonymous").Digits(), "anonymous"; got != want {
+		t.Errorf("Digits() = %q, want %q", got, want)
+	}
+}
+
+func TestPhoneNumber_Equal(t *testing.T) {
+	tests := []struct {
+		a, b PhoneNumber
+		want bool
+	}{
+		{"4512345678", "12 34 56 78", true},
+		{"+4512345678", "0045 12345678", true},
+		{"4512345678", "4587654321", false},
+		{"anonymous", "anonymous", true},
+		{"anonymous", "12345678", false},
+	}
+
+	for _, test := range tests {
+		if got := test.a.Equal(test.b); got != test.want {
+			t.Errorf("PhoneNumber(%q).Equal(%q) = %v, want %v", test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestCallsService_All_NumberOption(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/calls", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got, want := r.URL.Query().Get("number"), "4512345678"; got != want {
+			t.Errorf("number query parameter is %q, want %q", got, want)
+		}
+		w.Write([]byte(`{"calls":[]}`))
+	})
+
+	_, _, err := client.Calls.GetAll(&CallsListOptions{Number: "+45 12 34 56 78"})
+	if err != nil {
+		t.Errorf("Get all calls returned error: %v", err)
+	}
+}
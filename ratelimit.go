@@ -0,0 +1,22 @@
+package firmafon
+
+import (
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimit returns middleware that waits for l before sending each request.
+// If the request's context is cancelled while waiting, the request is not
+// sent and the context error is returned.
+func RateLimit(l *rate.Limiter) Middleware {
+	return func(next DoFunc) DoFunc {
+		return func(req *http.Request, v interface{}) (*Response, error) {
+			if err := l.Wait(req.Context()); err != nil {
+				return nil, err
+			}
+
+			return next(req, v)
+		}
+	}
+}
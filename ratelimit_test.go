@@ -0,0 +1,44 @@
+package firmafon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestRateLimit(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/employee", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"employee":{"id":1}}`)
+	})
+
+	client.Use(RateLimit(rate.NewLimiter(rate.Every(50*time.Millisecond), 1)))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, _, err := client.Employees.Authenticated(); err != nil {
+			t.Fatalf("Authenticated returned error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("3 requests took %v, want at least 100ms", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := client.WithContext(ctx).Employees.Authenticated(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Authenticated returned %v, want context.Canceled", err)
+	}
+	if requests != 3 {
+		t.Errorf("server got %d requests, want 3", requests)
+	}
+}
@@ -0,0 +1,111 @@
+package firmafon
+
+import (
+	"fmt"
+	"time"
+)
+
+type ReceptionService service
+
+// QueuedCall is an incoming call waiting in the reception queue.
+type QueuedCall struct {
+	CallUUID    string           `json:"call_uuid"`
+	FromNumber  string           `json:"from_number"`
+	FromContact *CallFromContact `json:"from_contact"`
+	ToNumber    string           `json:"to_number"`
+
+	// Position is the place of the call in the queue, starting at 1 for the
+	// call that is answered next.
+	Position int `json:"position"`
+
+	// QueuedAt is when the call entered the queue.
+	QueuedAt time.Time `json:"queued_at"`
+}
+
+// WaitTime returns how long the call has been waiting at now.
+func (c *QueuedCall) WaitTime(now time.Time) time.Duration {
+	if c.QueuedAt.IsZero() || now.Before(c.QueuedAt) {
+		return 0
+	}
+
+	return now.Sub(c.QueuedAt)
+}
+
+type firmafonQueue struct {
+	Queue []*QueuedCall `json:"queue"`
+}
+
+// Queue returns the calls waiting in the reception queue, ordered by
+// position.
+func (s *ReceptionService) Queue() ([]*QueuedCall, *Response, error) {
+	req, err := s.client.NewRequest("GET", "reception/queue", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "Reception", "Queue")
+
+	q := new(firmafonQueue)
+	resp, err := s.client.Do(req, &q)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return q.Queue, resp, nil
+}
+
+// SignedIn returns the employees signed into the reception, i.e. the ones
+// who are offered calls from the queue.
+func (s *ReceptionService) SignedIn() ([]*Employee, *Response, error) {
+	req, err := s.client.NewRequest("GET", "reception/employees", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "Reception", "SignedIn")
+
+	emps := new(firmafonEmployees)
+	resp, err := s.client.Do(req, &emps)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return emps.Employees, resp, nil
+}
+
+// SignIn signs an employee into the reception. Only administrators can sign
+// in other employees.
+func (s *ReceptionService) SignIn(employeeID int) (*Response, error) {
+	url := fmt.Sprintf("reception/employees/%d", employeeID)
+	req, err := s.client.NewRequest("POST", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = withOperation(req, "Reception", "SignIn")
+
+	resp, err := s.client.Do(req, nil)
+	s.invalidateEmployee(employeeID)
+
+	return resp, err
+}
+
+// SignOut signs an employee out of the reception. Only administrators can
+// sign out other employees.
+func (s *ReceptionService) SignOut(employeeID int) (*Response, error) {
+	url := fmt.Sprintf("reception/employees/%d", employeeID)
+	req, err := s.client.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = withOperation(req, "Reception", "SignOut")
+
+	resp, err := s.client.Do(req, nil)
+	s.invalidateEmployee(employeeID)
+
+	return resp, err
+}
+
+// invalidateEmployee drops a cached employee whose reception state changed.
+func (s *ReceptionService) invalidateEmployee(id int) {
+	if ec := s.client.employeeCache; ec != nil {
+		ec.invalidate(id)
+	}
+}
@@ -0,0 +1,172 @@
+package firmafon
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReceptionService_Queue(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/reception/queue", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", mediaTypeJSON)
+		fmt.Fprint(w, `{
+		  "queue": [
+			{
+			  "call_uuid": "6d3e3a5c-1f5e-4b0a-9f0e-2a2d4c9d1e01",
+			  "from_number": "4512345678",
+			  "from_contact": {"id": 7, "number": "4512345678", "name": "John Doe", "email": "john@example.com"},
+			  "to_number": "4571999999",
+			  "position": 1,
+			  "queued_at": "2014-03-21T13:59:04Z"
+			},
+			{
+			  "call_uuid": "6d3e3a5c-1f5e-4b0a-9f0e-2a2d4c9d1e02",
+			  "from_number": "4587654321",
+			  "from_contact": null,
+			  "to_number": "4571999999",
+			  "position": 2,
+			  "queued_at": "2014-03-21T14:00:34Z"
+			}
+		  ]
+		}`)
+	})
+
+	queue, _, err := client.Reception.Queue()
+	if err != nil {
+		t.Errorf("Reception.Queue returned error: %v", err)
+	}
+
+	want := []*QueuedCall{
+		{
+			CallUUID:    "6d3e3a5c-1f5e-4b0a-9f0e-2a2d4c9d1e01",
+			FromNumber:  "4512345678",
+			FromContact: &CallFromContact{ID: 7, Number: "4512345678", Name: "John Doe", Email: "john@example.com"},
+			ToNumber:    "4571999999",
+			Position:    1,
+			QueuedAt:    time.Date(2014, 3, 21, 13, 59, 4, 0, time.UTC),
+		},
+		{
+			CallUUID:   "6d3e3a5c-1f5e-4b0a-9f0e-2a2d4c9d1e02",
+			FromNumber: "4587654321",
+			ToNumber:   "4571999999",
+			Position:   2,
+			QueuedAt:   time.Date(2014, 3, 21, 14, 0, 34, 0, time.UTC),
+		},
+	}
+	if !reflect.DeepEqual(queue, want) {
+		t.Errorf("Reception.Queue returned %+v, want %+v", queue, want)
+	}
+}
+
+func TestQueuedCall_WaitTime(t *testing.T) {
+	queued := time.Date(2014, 3, 21, 13, 59, 4, 0, time.UTC)
+	c := &QueuedCall{QueuedAt: queued}
+
+	tests := []struct {
+		now  time.Time
+		want time.Duration
+	}{
+		{queued.Add(90 * time.Second), 90 * time.Second},
+		{queued, 0},
+		{queued.Add(-time.Second), 0},
+	}
+	for _, tt := range tests {
+		if got := c.WaitTime(tt.now); got != tt.want {
+			t.Errorf("WaitTime(%v) returned %v, want %v", tt.now, got, tt.want)
+		}
+	}
+
+	if got := (&QueuedCall{}).WaitTime(queued); got != 0 {
+		t.Errorf("WaitTime without QueuedAt returned %v, want 0", got)
+	}
+}
+
+func TestReceptionService_SignedIn(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/reception/employees", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"employees": [{"id": 1, "name": "Receptionist", "number": "4512345678"}]}`)
+	})
+
+	emps, _, err := client.Reception.SignedIn()
+	if err != nil {
+		t.Errorf("Reception.SignedIn returned error: %v", err)
+	}
+
+	want := []*Employee{{ID: 1, Name: "Receptionist", Number: "4512345678"}}
+	if !reflect.DeepEqual(emps, want) {
+		t.Errorf("Reception.SignedIn returned %+v, want %+v", emps, want)
+	}
+}
+
+func TestReceptionService_SignIn(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/reception/employees/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := client.Reception.SignIn(1); err != nil {
+		t.Errorf("Reception.SignIn returned error: %v", err)
+	}
+}
+
+func TestReceptionService_SignOut(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/reception/employees/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := client.Reception.SignOut(1); err != nil {
+		t.Errorf("Reception.SignOut returned error: %v", err)
+	}
+}
+
+func TestReceptionService_SignIn_invalidatesEmployeeCache(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.EnableEmployeeCache(nil)
+
+	requests := 0
+	mux.HandleFunc("/employees/1", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"employee": {"id": 1}}`)
+	})
+	mux.HandleFunc("/reception/employees/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	client.Employees.GetById(1)
+	client.Reception.SignIn(1)
+	client.Employees.GetById(1)
+
+	if requests != 2 {
+		t.Errorf("GetById made %d requests, want 2", requests)
+	}
+}
+
+func TestReceptionService_Queue_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/reception/queue", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	})
+
+	if _, _, err := client.Reception.Queue(); err == nil {
+		t.Error("Reception.Queue expected an error but got none")
+	}
+}
@@ -0,0 +1,132 @@
+package firmafon
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+const mediaTypeAudio = "audio/*"
+
+type VoicemailsService service
+
+// Voicemail is a message left by a caller.
+type Voicemail struct {
+	ID         int       `json:"id"`
+	EmployeeID int       `json:"employee_id"`
+	CallUUID   string    `json:"call_uuid"`
+	FromNumber string    `json:"from_number"`
+	Duration   int       `json:"duration"` // seconds
+	Read       bool      `json:"read"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// VoicemailsListOptions filters the voicemails returned by
+// VoicemailsService.List. Times are RFC 3339, like in CallsListOptions.
+type VoicemailsListOptions struct {
+	EmployeeID      int    `url:"employee_id,omitempty"`
+	CreatedAtGtOrEq string `url:"created_at_gt_or_eq,omitempty"`
+	CreatedAtLtOrEq string `url:"created_at_lt_or_eq,omitempty"`
+}
+
+type firmafonVoicemails struct {
+	Voicemails []*Voicemail `json:"voicemails"`
+}
+
+type firmafonVoicemail struct {
+	Voicemail *Voicemail `json:"voicemail"`
+}
+
+type firmafonVoicemailRead struct {
+	Voicemail struct {
+		Read bool `json:"read"`
+	} `json:"voicemail"`
+}
+
+// List returns the voicemails the authenticated employee can access. opt may
+// be nil.
+func (s *VoicemailsService) List(opt *VoicemailsListOptions) ([]*Voicemail, *Response, error) {
+	url, err := addOptions("voicemails", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "Voicemails", "List")
+
+	vms := new(firmafonVoicemails)
+	resp, err := s.client.Do(req, &vms)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return vms.Voicemails, resp, nil
+}
+
+// Get returns a voicemail by ID.
+func (s *VoicemailsService) Get(id int) (*Voicemail, *Response, error) {
+	url := fmt.Sprintf("voicemails/%d", id)
+	req, err := s.client.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "Voicemails", "Get")
+
+	vm := new(firmafonVoicemail)
+	resp, err := s.client.Do(req, &vm)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return vm.Voicemail, resp, nil
+}
+
+// MarkRead marks a voicemail as read.
+func (s *VoicemailsService) MarkRead(id int) (*Voicemail, *Response, error) {
+	url := fmt.Sprintf("voicemails/%d", id)
+	body := new(firmafonVoicemailRead)
+	body.Voicemail.Read = true
+
+	req, err := s.client.NewRequest("PUT", url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	req = withOperation(req, "Voicemails", "MarkRead")
+
+	vm := new(firmafonVoicemail)
+	resp, err := s.client.Do(req, &vm)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return vm.Voicemail, resp, nil
+}
+
+// Delete deletes a voicemail.
+func (s *VoicemailsService) Delete(id int) (*Response, error) {
+	url := fmt.Sprintf("voicemails/%d", id)
+	req, err := s.client.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = withOperation(req, "Voicemails", "Delete")
+
+	return s.client.Do(req, nil)
+}
+
+// Audio streams the audio of a voicemail to w. The Content-Type header of the
+// returned response holds the audio format. Audio is never stored in
+// Client.HTTPCache.
+func (s *VoicemailsService) Audio(id int, w io.Writer) (*Response, error) {
+	url := fmt.Sprintf("voicemails/%d/audio", id)
+	req, err := s.client.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", mediaTypeAudio)
+	req = withOperation(req, "Voicemails", "Audio")
+
+	return s.client.Do(req, w)
+}
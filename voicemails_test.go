@@ -0,0 +1,197 @@
+package firmafon
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVoicemailsService_List(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/voicemails", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", mediaTypeJSON)
+		want := url.Values{"employee_id": {"2"}, "created_at_gt_or_eq": {"2014-03-21T00:00:00Z"}}
+		if got := r.URL.Query(); !reflect.DeepEqual(got, want) {
+			t.Errorf("query = %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{
+		  "voicemails": [
+			{
+			  "id": 1,
+			  "employee_id": 2,
+			  "call_uuid": "e54f5820-386d-0132-5bc3-14dae9edd21d",
+			  "from_number": "4512345678",
+			  "duration": 17,
+			  "read": false,
+			  "created_at": "2014-03-21T13:59:04Z"
+			}
+		  ]
+		}`)
+	})
+
+	opt := &VoicemailsListOptions{EmployeeID: 2, CreatedAtGtOrEq: "2014-03-21T00:00:00Z"}
+	vms, _, err := client.Voicemails.List(opt)
+	if err != nil {
+		t.Errorf("Voicemails.List returned error: %v", err)
+	}
+
+	want := []*Voicemail{{
+		ID:         1,
+		EmployeeID: 2,
+		CallUUID:   "e54f5820-386d-0132-5bc3-14dae9edd21d",
+		FromNumber: "4512345678",
+		Duration:   17,
+		CreatedAt:  time.Date(2014, 3, 21, 13, 59, 4, 0, time.UTC),
+	}}
+	if !reflect.DeepEqual(vms, want) {
+		t.Errorf("Voicemails.List returned %+v, want %+v", vms, want)
+	}
+}
+
+func TestVoicemailsService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/voicemails/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"voicemail": {"id": 1, "read": true}}`)
+	})
+
+	vm, _, err := client.Voicemails.Get(1)
+	if err != nil {
+		t.Errorf("Voicemails.Get returned error: %v", err)
+	}
+
+	if want := (&Voicemail{ID: 1, Read: true}); !reflect.DeepEqual(vm, want) {
+		t.Errorf("Voicemails.Get returned %+v, want %+v", vm, want)
+	}
+}
+
+func TestVoicemailsService_MarkRead(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/voicemails/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		body, _ := io.ReadAll(r.Body)
+		if got, want := strings.TrimSpace(string(body)), `{"voicemail":{"read":true}}`; got != want {
+			t.Errorf("request body = %s, want %s", got, want)
+		}
+		fmt.Fprint(w, `{"voicemail": {"id": 1, "read": true}}`)
+	})
+
+	vm, _, err := client.Voicemails.MarkRead(1)
+	if err != nil {
+		t.Errorf("Voicemails.MarkRead returned error: %v", err)
+	}
+
+	if want := (&Voicemail{ID: 1, Read: true}); !reflect.DeepEqual(vm, want) {
+		t.Errorf("Voicemails.MarkRead returned %+v, want %+v", vm, want)
+	}
+}
+
+func TestVoicemailsService_Delete(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/voicemails/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := client.Voicemails.Delete(1); err != nil {
+		t.Errorf("Voicemails.Delete returned error: %v", err)
+	}
+}
+
+func TestVoicemailsService_Audio(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/voicemails/1/audio", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Accept", mediaTypeAudio)
+		w.Header().Set("Content-Type", "audio/mpeg")
+		fmt.Fprint(w, "ID3\x03audio")
+	})
+
+	var buf bytes.Buffer
+	resp, err := client.Voicemails.Audio(1, &buf)
+	if err != nil {
+		t.Errorf("Voicemails.Audio returned error: %v", err)
+	}
+
+	if got, want := buf.String(), "ID3\x03audio"; got != want {
+		t.Errorf("Voicemails.Audio wrote %q, want %q", got, want)
+	}
+	if got, want := resp.Header.Get("Content-Type"), "audio/mpeg"; got != want {
+		t.Errorf("Content-Type = %q, want %q", got, want)
+	}
+}
+
+func TestVoicemailsService_Audio_notCached(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/voicemails/1/audio", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("If-None-Match"); got != "" {
+			t.Errorf("If-None-Match = %q, want none", got)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Header().Set("ETag", `"v2"`)
+		fmt.Fprint(w, "ID3\x03audio")
+	})
+
+	// an entry for the audio URL, e.g. stored by an older version, must
+	// neither be revalidated nor replayed
+	cache := NewMemoryHTTPCache()
+	client.HTTPCache = cache
+	req, _ := client.NewRequest("GET", "voicemails/1/audio", nil)
+	key := httpCacheKey(req)
+	cache.Set(key, &HTTPCacheEntry{ETag: `"v1"`, Body: []byte("stale")})
+
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		resp, err := client.Voicemails.Audio(1, &buf)
+		if err != nil {
+			t.Fatalf("Voicemails.Audio returned error: %v", err)
+		}
+		if resp.Cached {
+			t.Errorf("request %d: response is marked as cached", i)
+		}
+		if got, want := buf.String(), "ID3\x03audio"; got != want {
+			t.Errorf("Voicemails.Audio wrote %q, want %q", got, want)
+		}
+	}
+	if entry, _ := cache.Get(key); string(entry.Body) != "stale" || len(cache.entries) != 1 {
+		t.Errorf("cache was modified: %d entries, body %q", len(cache.entries), entry.Body)
+	}
+}
+
+func TestVoicemailsService_Audio_error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/voicemails/1/audio", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	var buf bytes.Buffer
+	if _, err := client.Voicemails.Audio(1, &buf); err == nil {
+		t.Error("Voicemails.Audio expected an error but got none")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Voicemails.Audio wrote the error body %q", buf.String())
+	}
+}